@@ -187,7 +187,10 @@ func NewControllerManagerCommand(ctx context.Context) *cobra.Command {
 			healthCheckCtrlOpts.Completed().Apply(&healthcheck.DefaultAddOptions.Controller)
 			heartbeatCtrlOpts.Completed().Apply(&heartbeat.DefaultAddOptions)
 			infraCtrlOpts.Completed().Apply(&infrastructurecontroller.DefaultAddOptions.Controller)
+			configFileOpts.Completed().ApplyTenantBootstrap(&infrastructurecontroller.DefaultAddOptions.TenantBootstrap)
 			workerCtrlOpts.Completed().Apply(&workercontroller.DefaultAddOptions.Controller)
+			configFileOpts.Completed().ApplyTenantBootstrap(&workercontroller.DefaultAddOptions.TenantBootstrap)
+			configFileOpts.Completed().ApplyServerSanitization(&workercontroller.DefaultAddOptions.ServerSanitization)
 			reconcileOpts.Completed().Apply(&infrastructurecontroller.DefaultAddOptions.IgnoreOperationAnnotation, &infrastructurecontroller.DefaultAddOptions.ExtensionClass)
 			reconcileOpts.Completed().Apply(&workercontroller.DefaultAddOptions.IgnoreOperationAnnotation, &workercontroller.DefaultAddOptions.ExtensionClass)
 			workercontroller.DefaultAddOptions.GardenCluster = gardenCluster
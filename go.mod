@@ -10,7 +10,6 @@ require (
 	github.com/gardener/gardener v1.110.1
 	github.com/gardener/machine-controller-manager v0.55.1
 	github.com/go-logr/logr v1.4.2
-	github.com/imdario/mergo v0.3.16
 	github.com/ironcore-dev/controller-utils v0.9.7
 	github.com/ironcore-dev/vgopath v0.1.7
 	github.com/onsi/ginkgo/v2 v2.22.2
@@ -29,3 +29,13 @@ func DecodeInfrastructureConfig(decoder runtime.Decoder, infra *runtime.RawExten
 
 	return infraConfig, nil
 }
+
+// DecodeWorkerConfig decodes the `WorkerConfig` from the given `RawExtension`.
+func DecodeWorkerConfig(decoder runtime.Decoder, worker *runtime.RawExtension) (*metal.WorkerConfig, error) {
+	workerConfig := &metal.WorkerConfig{}
+	if err := util.Decode(decoder, worker.Raw, workerConfig); err != nil {
+		return nil, err
+	}
+
+	return workerConfig, nil
+}
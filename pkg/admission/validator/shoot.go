@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"reflect"
 
+	"github.com/gardener/gardener/extensions/pkg/util"
 	extensionswebhook "github.com/gardener/gardener/extensions/pkg/webhook"
 	"github.com/gardener/gardener/pkg/apis/core"
 	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
@@ -20,6 +21,7 @@ import (
 	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/admission"
 	apismetal "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal"
 	metalvalidation "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal/validation"
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/metal/helper"
 )
 
 type shoot struct {
@@ -31,7 +33,9 @@ type shoot struct {
 // NewShootValidator returns a new instance of a shoot validator.
 func NewShootValidator(mgr manager.Manager) extensionswebhook.Validator {
 	return &shoot{
-		decoder: serializer.NewCodecFactory(mgr.GetScheme(), serializer.EnableStrict).UniversalDecoder(),
+		client:         mgr.GetClient(),
+		decoder:        serializer.NewCodecFactory(mgr.GetScheme(), serializer.EnableStrict).UniversalDecoder(),
+		lenientDecoder: serializer.NewCodecFactory(mgr.GetScheme()).UniversalDecoder(),
 	}
 }
 
@@ -68,7 +72,9 @@ type validationContext struct {
 	shoot                *core.Shoot
 	infrastructureConfig *apismetal.InfrastructureConfig
 	controlPlaneConfig   *apismetal.ControlPlaneConfig
+	workerConfigs        []*apismetal.WorkerConfig
 	cloudProfile         *gardencorev1beta1.CloudProfile
+	cloudProfileConfig   *apismetal.CloudProfileConfig
 }
 
 func (s *shoot) validateContext(valContext *validationContext) field.ErrorList {
@@ -77,8 +83,24 @@ func (s *shoot) validateContext(valContext *validationContext) field.ErrorList {
 	)
 
 	allErrors = append(allErrors, metalvalidation.ValidateNetworking(valContext.shoot.Spec.Networking, networkPath)...)
-	allErrors = append(allErrors, metalvalidation.ValidateInfrastructureConfig(valContext.infrastructureConfig, valContext.shoot.Spec.Networking.Nodes, valContext.shoot.Spec.Networking.Pods, valContext.shoot.Spec.Networking.Services, infrastructureConfigPath)...)
-	allErrors = append(allErrors, metalvalidation.ValidateWorkers(valContext.shoot.Spec.Provider.Workers, workersPath)...)
+	if networking := valContext.shoot.Spec.Networking; networking != nil {
+		allErrors = append(allErrors, metalvalidation.ValidateInfrastructureConfig(valContext.infrastructureConfig, networking.IPFamilies, networking.Nodes, networking.Pods, networking.Services, infrastructureConfigPath)...)
+	}
+	allErrors = append(allErrors, metalvalidation.ValidateWorkers(valContext.shoot.Spec.Provider.Workers, valContext.workerConfigs, workersPath)...)
+	for i, workerConfig := range valContext.workerConfigs {
+		if workerConfig == nil {
+			continue
+		}
+		worker := valContext.shoot.Spec.Provider.Workers[i]
+		providerConfigPath := workersPath.Index(i).Child("providerConfig")
+		allErrors = append(allErrors, metalvalidation.ValidateWorkerConfig(workerConfig, providerConfigPath)...)
+		allErrors = append(allErrors, metalvalidation.ValidateWorkerConfigZones(workerConfig, worker.Zones, providerConfigPath)...)
+		if workerConfig.Storage != nil {
+			if diskCount, ok := helper.DiskCountForMachineType(valContext.cloudProfileConfig, worker.Machine.Type); ok {
+				allErrors = append(allErrors, metalvalidation.ValidateStorageConfigDisks(workerConfig.Storage, diskCount, providerConfigPath.Child("storage"))...)
+			}
+		}
+	}
 	allErrors = append(allErrors, metalvalidation.ValidateControlPlaneConfig(valContext.controlPlaneConfig, valContext.shoot.Spec.Kubernetes.Version, controlPlaneConfigPath)...)
 
 	return allErrors
@@ -142,6 +164,18 @@ func newValidationContext(ctx context.Context, decoder runtime.Decoder, c client
 		return nil, fmt.Errorf("error decoding controlPlaneConfig: %v", err)
 	}
 
+	workerConfigs := make([]*apismetal.WorkerConfig, len(shoot.Spec.Provider.Workers))
+	for i, worker := range shoot.Spec.Provider.Workers {
+		if worker.ProviderConfig == nil {
+			continue
+		}
+		workerConfig, err := admission.DecodeWorkerConfig(decoder, worker.ProviderConfig)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding providerConfig of worker %s: %v", worker.Name, err)
+		}
+		workerConfigs[i] = workerConfig
+	}
+
 	cloudProfile := &gardencorev1beta1.CloudProfile{}
 	if err := c.Get(ctx, client.ObjectKey{Name: shoot.Spec.CloudProfile.Name}, cloudProfile); err != nil {
 		return nil, err
@@ -150,11 +184,17 @@ func newValidationContext(ctx context.Context, decoder runtime.Decoder, c client
 	if cloudProfile.Spec.ProviderConfig == nil {
 		return nil, fmt.Errorf("providerConfig is not given for cloud profile %q", cloudProfile.Name)
 	}
+	cloudProfileConfig := &apismetal.CloudProfileConfig{}
+	if err := util.Decode(decoder, cloudProfile.Spec.ProviderConfig.Raw, cloudProfileConfig); err != nil {
+		return nil, fmt.Errorf("error decoding providerConfig of cloud profile %q: %v", cloudProfile.Name, err)
+	}
 
 	return &validationContext{
 		shoot:                shoot,
 		infrastructureConfig: infrastructureConfig,
 		controlPlaneConfig:   controlPlaneConfig,
+		workerConfigs:        workerConfigs,
 		cloudProfile:         cloudProfile,
+		cloudProfileConfig:   cloudProfileConfig,
 	}, nil
 }
@@ -5,6 +5,7 @@ package config
 
 import (
 	healthcheckconfig "github.com/gardener/gardener/extensions/pkg/apis/config"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	componentbaseconfig "k8s.io/component-base/config"
@@ -27,6 +28,11 @@ type ControllerConfiguration struct {
 	// or disable alpha/experimental features.
 	// Default: nil
 	FeatureGates map[string]bool
+	// TenantBootstrap is the configuration for bootstrapping a dedicated tenant per shoot in the metal cluster.
+	TenantBootstrap *TenantBootstrap
+	// ServerSanitization is the configuration for verifying that the Servers released by worker pools with the Wipe
+	// release policy have been sanitized.
+	ServerSanitization *ServerSanitization
 }
 
 // ETCD is an etcd configuration.
@@ -50,3 +56,27 @@ type ETCDBackup struct {
 	// Schedule is the etcd backup schedule.
 	Schedule *string
 }
+
+// TenantBootstrap is the configuration for bootstrapping a dedicated namespace, Role and ServiceAccount per shoot
+// in the metal cluster.
+type TenantBootstrap struct {
+	// Regions contains the admin credentials of the regions in which tenants are bootstrapped.
+	Regions []TenantBootstrapRegion
+	// TokenExpiration is the validity of the tokens minted for the tenant service accounts.
+	TokenExpiration *metav1.Duration
+}
+
+// TenantBootstrapRegion contains the admin credentials of a region.
+type TenantBootstrapRegion struct {
+	// Name is the name of the region.
+	Name string
+	// SecretRef references the secret in the seed which contains the admin kubeconfig of the region's metal cluster.
+	SecretRef corev1.SecretReference
+}
+
+// ServerSanitization is the configuration for verifying that released Servers have been sanitized.
+type ServerSanitization struct {
+	// SkipUnverifiable stops waiting for released Servers which cannot be read with the admin credentials of the region,
+	// instead of failing the reconciliation of the Worker.
+	SkipUnverifiable bool
+}
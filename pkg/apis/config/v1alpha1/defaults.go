@@ -4,9 +4,19 @@
 package v1alpha1
 
 import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
 func addDefaultingFuncs(scheme *runtime.Scheme) error {
 	return RegisterDefaults(scheme)
 }
+
+// SetDefaults_TenantBootstrap sets default values for TenantBootstrap objects.
+func SetDefaults_TenantBootstrap(obj *TenantBootstrap) {
+	if obj.TokenExpiration == nil {
+		obj.TokenExpiration = &metav1.Duration{Duration: 24 * time.Hour}
+	}
+}
@@ -5,6 +5,7 @@ package v1alpha1
 
 import (
 	healthcheckconfigv1alpha1 "github.com/gardener/gardener/extensions/pkg/apis/config/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	componentbaseconfigv1alpha1 "k8s.io/component-base/config/v1alpha1"
@@ -31,6 +32,13 @@ type ControllerConfiguration struct {
 	// Default: nil
 	// +optional
 	FeatureGates map[string]bool `json:"featureGates,omitempty"`
+	// TenantBootstrap is the configuration for bootstrapping a dedicated tenant per shoot in the metal cluster.
+	// +optional
+	TenantBootstrap *TenantBootstrap `json:"tenantBootstrap,omitempty"`
+	// ServerSanitization is the configuration for verifying that the Servers released by worker pools with the Wipe
+	// release policy have been sanitized.
+	// +optional
+	ServerSanitization *ServerSanitization `json:"serverSanitization,omitempty"`
 }
 
 // ETCD is an etcd configuration.
@@ -57,3 +65,30 @@ type ETCDBackup struct {
 	// +optional
 	Schedule *string `json:"schedule,omitempty"`
 }
+
+// TenantBootstrap is the configuration for bootstrapping a dedicated namespace, Role and ServiceAccount per shoot
+// in the metal cluster.
+type TenantBootstrap struct {
+	// Regions contains the admin credentials of the regions in which tenants are bootstrapped.
+	// +optional
+	Regions []TenantBootstrapRegion `json:"regions,omitempty"`
+	// TokenExpiration is the validity of the tokens minted for the tenant service accounts.
+	// +optional
+	TokenExpiration *metav1.Duration `json:"tokenExpiration,omitempty"`
+}
+
+// TenantBootstrapRegion contains the admin credentials of a region.
+type TenantBootstrapRegion struct {
+	// Name is the name of the region.
+	Name string `json:"name"`
+	// SecretRef references the secret in the seed which contains the admin kubeconfig of the region's metal cluster.
+	SecretRef corev1.SecretReference `json:"secretRef"`
+}
+
+// ServerSanitization is the configuration for verifying that released Servers have been sanitized.
+type ServerSanitization struct {
+	// SkipUnverifiable stops waiting for released Servers which cannot be read with the admin credentials of the region,
+	// instead of failing the reconciliation of the Worker.
+	// +optional
+	SkipUnverifiable bool `json:"skipUnverifiable,omitempty"`
+}
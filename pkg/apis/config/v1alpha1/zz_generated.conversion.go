@@ -16,6 +16,7 @@ import (
 	apisconfigv1alpha1 "github.com/gardener/gardener/extensions/pkg/apis/config/v1alpha1"
 	config "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/config"
 	resource "k8s.io/apimachinery/pkg/api/resource"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	conversion "k8s.io/apimachinery/pkg/conversion"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	componentbaseconfig "k8s.io/component-base/config"
@@ -69,6 +70,36 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ServerSanitization)(nil), (*config.ServerSanitization)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ServerSanitization_To_config_ServerSanitization(a.(*ServerSanitization), b.(*config.ServerSanitization), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.ServerSanitization)(nil), (*ServerSanitization)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_ServerSanitization_To_v1alpha1_ServerSanitization(a.(*config.ServerSanitization), b.(*ServerSanitization), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*TenantBootstrap)(nil), (*config.TenantBootstrap)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_TenantBootstrap_To_config_TenantBootstrap(a.(*TenantBootstrap), b.(*config.TenantBootstrap), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.TenantBootstrap)(nil), (*TenantBootstrap)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_TenantBootstrap_To_v1alpha1_TenantBootstrap(a.(*config.TenantBootstrap), b.(*TenantBootstrap), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*TenantBootstrapRegion)(nil), (*config.TenantBootstrapRegion)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_TenantBootstrapRegion_To_config_TenantBootstrapRegion(a.(*TenantBootstrapRegion), b.(*config.TenantBootstrapRegion), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.TenantBootstrapRegion)(nil), (*TenantBootstrapRegion)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_TenantBootstrapRegion_To_v1alpha1_TenantBootstrapRegion(a.(*config.TenantBootstrapRegion), b.(*TenantBootstrapRegion), scope)
+	}); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -79,6 +110,8 @@ func autoConvert_v1alpha1_ControllerConfiguration_To_config_ControllerConfigurat
 	}
 	out.HealthCheckConfig = (*apisconfig.HealthCheckConfig)(unsafe.Pointer(in.HealthCheckConfig))
 	out.FeatureGates = *(*map[string]bool)(unsafe.Pointer(&in.FeatureGates))
+	out.TenantBootstrap = (*config.TenantBootstrap)(unsafe.Pointer(in.TenantBootstrap))
+	out.ServerSanitization = (*config.ServerSanitization)(unsafe.Pointer(in.ServerSanitization))
 	return nil
 }
 
@@ -94,6 +127,8 @@ func autoConvert_config_ControllerConfiguration_To_v1alpha1_ControllerConfigurat
 	}
 	out.HealthCheckConfig = (*apisconfigv1alpha1.HealthCheckConfig)(unsafe.Pointer(in.HealthCheckConfig))
 	out.FeatureGates = *(*map[string]bool)(unsafe.Pointer(&in.FeatureGates))
+	out.TenantBootstrap = (*TenantBootstrap)(unsafe.Pointer(in.TenantBootstrap))
+	out.ServerSanitization = (*ServerSanitization)(unsafe.Pointer(in.ServerSanitization))
 	return nil
 }
 
@@ -173,3 +208,67 @@ func autoConvert_config_ETCDStorage_To_v1alpha1_ETCDStorage(in *config.ETCDStora
 func Convert_config_ETCDStorage_To_v1alpha1_ETCDStorage(in *config.ETCDStorage, out *ETCDStorage, s conversion.Scope) error {
 	return autoConvert_config_ETCDStorage_To_v1alpha1_ETCDStorage(in, out, s)
 }
+
+func autoConvert_v1alpha1_ServerSanitization_To_config_ServerSanitization(in *ServerSanitization, out *config.ServerSanitization, s conversion.Scope) error {
+	out.SkipUnverifiable = in.SkipUnverifiable
+	return nil
+}
+
+// Convert_v1alpha1_ServerSanitization_To_config_ServerSanitization is an autogenerated conversion function.
+func Convert_v1alpha1_ServerSanitization_To_config_ServerSanitization(in *ServerSanitization, out *config.ServerSanitization, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ServerSanitization_To_config_ServerSanitization(in, out, s)
+}
+
+func autoConvert_config_ServerSanitization_To_v1alpha1_ServerSanitization(in *config.ServerSanitization, out *ServerSanitization, s conversion.Scope) error {
+	out.SkipUnverifiable = in.SkipUnverifiable
+	return nil
+}
+
+// Convert_config_ServerSanitization_To_v1alpha1_ServerSanitization is an autogenerated conversion function.
+func Convert_config_ServerSanitization_To_v1alpha1_ServerSanitization(in *config.ServerSanitization, out *ServerSanitization, s conversion.Scope) error {
+	return autoConvert_config_ServerSanitization_To_v1alpha1_ServerSanitization(in, out, s)
+}
+
+func autoConvert_v1alpha1_TenantBootstrap_To_config_TenantBootstrap(in *TenantBootstrap, out *config.TenantBootstrap, s conversion.Scope) error {
+	out.Regions = *(*[]config.TenantBootstrapRegion)(unsafe.Pointer(&in.Regions))
+	out.TokenExpiration = (*v1.Duration)(unsafe.Pointer(in.TokenExpiration))
+	return nil
+}
+
+// Convert_v1alpha1_TenantBootstrap_To_config_TenantBootstrap is an autogenerated conversion function.
+func Convert_v1alpha1_TenantBootstrap_To_config_TenantBootstrap(in *TenantBootstrap, out *config.TenantBootstrap, s conversion.Scope) error {
+	return autoConvert_v1alpha1_TenantBootstrap_To_config_TenantBootstrap(in, out, s)
+}
+
+func autoConvert_config_TenantBootstrap_To_v1alpha1_TenantBootstrap(in *config.TenantBootstrap, out *TenantBootstrap, s conversion.Scope) error {
+	out.Regions = *(*[]TenantBootstrapRegion)(unsafe.Pointer(&in.Regions))
+	out.TokenExpiration = (*v1.Duration)(unsafe.Pointer(in.TokenExpiration))
+	return nil
+}
+
+// Convert_config_TenantBootstrap_To_v1alpha1_TenantBootstrap is an autogenerated conversion function.
+func Convert_config_TenantBootstrap_To_v1alpha1_TenantBootstrap(in *config.TenantBootstrap, out *TenantBootstrap, s conversion.Scope) error {
+	return autoConvert_config_TenantBootstrap_To_v1alpha1_TenantBootstrap(in, out, s)
+}
+
+func autoConvert_v1alpha1_TenantBootstrapRegion_To_config_TenantBootstrapRegion(in *TenantBootstrapRegion, out *config.TenantBootstrapRegion, s conversion.Scope) error {
+	out.Name = in.Name
+	out.SecretRef = in.SecretRef
+	return nil
+}
+
+// Convert_v1alpha1_TenantBootstrapRegion_To_config_TenantBootstrapRegion is an autogenerated conversion function.
+func Convert_v1alpha1_TenantBootstrapRegion_To_config_TenantBootstrapRegion(in *TenantBootstrapRegion, out *config.TenantBootstrapRegion, s conversion.Scope) error {
+	return autoConvert_v1alpha1_TenantBootstrapRegion_To_config_TenantBootstrapRegion(in, out, s)
+}
+
+func autoConvert_config_TenantBootstrapRegion_To_v1alpha1_TenantBootstrapRegion(in *config.TenantBootstrapRegion, out *TenantBootstrapRegion, s conversion.Scope) error {
+	out.Name = in.Name
+	out.SecretRef = in.SecretRef
+	return nil
+}
+
+// Convert_config_TenantBootstrapRegion_To_v1alpha1_TenantBootstrapRegion is an autogenerated conversion function.
+func Convert_config_TenantBootstrapRegion_To_v1alpha1_TenantBootstrapRegion(in *config.TenantBootstrapRegion, out *TenantBootstrapRegion, s conversion.Scope) error {
+	return autoConvert_config_TenantBootstrapRegion_To_v1alpha1_TenantBootstrapRegion(in, out, s)
+}
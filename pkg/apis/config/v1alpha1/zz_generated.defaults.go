@@ -17,5 +17,12 @@ import (
 // Public to allow building arbitrary schemes.
 // All generated defaulters are covering - they call all nested defaulters.
 func RegisterDefaults(scheme *runtime.Scheme) error {
+	scheme.AddTypeDefaultingFunc(&ControllerConfiguration{}, func(obj interface{}) { SetObjectDefaults_ControllerConfiguration(obj.(*ControllerConfiguration)) })
 	return nil
 }
+
+func SetObjectDefaults_ControllerConfiguration(in *ControllerConfiguration) {
+	if in.TenantBootstrap != nil {
+		SetDefaults_TenantBootstrap(in.TenantBootstrap)
+	}
+}
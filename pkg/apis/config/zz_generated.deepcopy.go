@@ -11,6 +11,7 @@ package config
 
 import (
 	apisconfig "github.com/gardener/gardener/extensions/pkg/apis/config"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	componentbaseconfig "k8s.io/component-base/config"
 )
@@ -37,6 +38,16 @@ func (in *ControllerConfiguration) DeepCopyInto(out *ControllerConfiguration) {
 			(*out)[key] = val
 		}
 	}
+	if in.TenantBootstrap != nil {
+		in, out := &in.TenantBootstrap, &out.TenantBootstrap
+		*out = new(TenantBootstrap)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServerSanitization != nil {
+		in, out := &in.ServerSanitization, &out.ServerSanitization
+		*out = new(ServerSanitization)
+		**out = **in
+	}
 	return
 }
 
@@ -122,3 +133,62 @@ func (in *ETCDStorage) DeepCopy() *ETCDStorage {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServerSanitization) DeepCopyInto(out *ServerSanitization) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerSanitization.
+func (in *ServerSanitization) DeepCopy() *ServerSanitization {
+	if in == nil {
+		return nil
+	}
+	out := new(ServerSanitization)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantBootstrap) DeepCopyInto(out *TenantBootstrap) {
+	*out = *in
+	if in.Regions != nil {
+		in, out := &in.Regions, &out.Regions
+		*out = make([]TenantBootstrapRegion, len(*in))
+		copy(*out, *in)
+	}
+	if in.TokenExpiration != nil {
+		in, out := &in.TokenExpiration, &out.TokenExpiration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantBootstrap.
+func (in *TenantBootstrap) DeepCopy() *TenantBootstrap {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantBootstrap)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantBootstrapRegion) DeepCopyInto(out *TenantBootstrapRegion) {
+	*out = *in
+	out.SecretRef = in.SecretRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantBootstrapRegion.
+func (in *TenantBootstrapRegion) DeepCopy() *TenantBootstrapRegion {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantBootstrapRegion)
+	in.DeepCopyInto(out)
+	return out
+}
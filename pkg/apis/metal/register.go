@@ -37,6 +37,7 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&CloudProfileConfig{},
 		&InfrastructureConfig{},
 		&InfrastructureStatus{},
+		&InfrastructureState{},
 		&ControlPlaneConfig{},
 		&WorkerConfig{},
 		&WorkerStatus{},
@@ -20,11 +20,15 @@ type CloudProfileConfig struct {
 	// RegionConfigs is the list of supported regions.
 	RegionConfigs []RegionConfig
 	MachineTypes  []MachineType
+	// HostnamePolicy is the default hostname policy of the machines of all Shoots using the CloudProfile.
+	HostnamePolicy *HostnamePolicy
 }
 
 type MachineType struct {
 	Name         string
 	ServerLabels map[string]string
+	// ServerSelector is a label selector for the Servers of the machine type in addition to the ServerLabels.
+	ServerSelector *metav1.LabelSelector
 }
 
 // MachineImages is a mapping from logical names and versions to provider-specific identifiers.
@@ -43,6 +47,17 @@ type RegionConfig struct {
 	Server string
 	// CertificateAuthorityData is the CA data of the region server.
 	CertificateAuthorityData []byte
+	// Zones is the list of zones of this region.
+	Zones []ZoneConfig
+}
+
+// ZoneConfig is the definition of a zone within a region.
+type ZoneConfig struct {
+	// Name is the name of the zone.
+	Name string
+	// ServerLabels are the labels of the servers located in this zone, e.g. a rack or room label. They are added to the
+	// server labels of the MachineClasses of this zone.
+	ServerLabels map[string]string
 }
 
 // MachineImageVersion contains a version and a provider-specific identifier.
@@ -16,6 +16,15 @@ type InfrastructureConfig struct {
 
 	// Networks is the metal specific network configuration.
 	Networks []Networks
+	// LoadBalancerPrefix configures the reservation of a LoadBalancer prefix from an IPAM parent subnet.
+	LoadBalancerPrefix *LoadBalancerPrefix
+	// IPv6PodsCIDR is the IPv6 pod network of a dual-stack shoot, whose spec.networking.pods is the IPv4 pod network.
+	IPv6PodsCIDR string
+	// IPv6ServicesCIDR is the IPv6 service network of a dual-stack shoot, whose spec.networking.services is the IPv4
+	// service network.
+	IPv6ServicesCIDR string
+	// IPv6LoadBalancerPrefix configures the reservation of an IPv6 LoadBalancer prefix of a dual-stack shoot.
+	IPv6LoadBalancerPrefix *LoadBalancerPrefix
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -23,14 +32,84 @@ type InfrastructureConfig struct {
 // InfrastructureStatus contains information about created infrastructure resources.
 type InfrastructureStatus struct {
 	metav1.TypeMeta
+
+	// Networks contains information about the networks provisioned in the metal cluster.
+	Networks []NetworkStatus
+	// LoadBalancerPrefix contains information about the LoadBalancer prefix reserved in the metal cluster.
+	LoadBalancerPrefix *LoadBalancerPrefixStatus
+	// IPv6LoadBalancerPrefix contains information about the IPv6 LoadBalancer prefix reserved in the metal cluster.
+	IPv6LoadBalancerPrefix *LoadBalancerPrefixStatus
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// InfrastructureState contains the state of the infrastructure resources in the metal cluster. It is persisted
+// in the Infrastructure during control plane migration to adopt the resources on the destination seed.
+type InfrastructureState struct {
+	metav1.TypeMeta
+
+	// Networks contains the networks which have been provisioned in the metal cluster.
+	Networks []NetworkStatus
+	// LoadBalancerPrefix contains the LoadBalancer prefix which has been reserved in the metal cluster.
+	LoadBalancerPrefix *LoadBalancerPrefixStatus
+	// IPv6LoadBalancerPrefix contains the IPv6 LoadBalancer prefix which has been reserved in the metal cluster.
+	IPv6LoadBalancerPrefix *LoadBalancerPrefixStatus
 }
 
 // Networks holds information about the Kubernetes and infrastructure networks.
 type Networks struct {
 	// Name is the name for this network.
 	Name string
-	// CIDR is the workers subnet range to create.
+	// CIDR is the IPv4 workers subnet range to create. It is required if the shoot uses the IPv4 family.
 	CIDR string
+	// IPv6CIDR is the IPv6 workers subnet range to create. It is required if the shoot uses the IPv6 family.
+	IPv6CIDR string
 	// ID is the ID for the workers' subnet.
 	ID string
+	// Gateway is the IPv4 gateway address of the network. Defaults to the first address of the CIDR.
+	Gateway string
+	// IPv6Gateway is the IPv6 gateway address of the network. Defaults to the first address of the IPv6CIDR.
+	IPv6Gateway string
+	// DNSResolvers is a list of DNS resolver addresses of the network.
+	DNSResolvers []string
+}
+
+// NetworkStatus contains information about a network provisioned in the metal cluster.
+type NetworkStatus struct {
+	// Name is the name of the network.
+	Name string
+	// ID is the resolved ID of the network.
+	ID string
+	// CIDR is the CIDR of the network's IPv4 subnet.
+	CIDR string
+	// IPv6CIDR is the CIDR of the network's IPv6 subnet.
+	IPv6CIDR string
+	// Gateway is the IPv4 gateway address of the network.
+	Gateway string
+	// IPv6Gateway is the IPv6 gateway address of the network.
+	IPv6Gateway string
+	// DNSResolvers is a list of DNS resolver addresses of the network.
+	DNSResolvers []string
+	// NetworkRef is a reference to the IPAM Network object.
+	NetworkRef *IPAMObjectReference
+	// SubnetRef is a reference to the IPAM Subnet object of the IPv4 CIDR.
+	SubnetRef *IPAMObjectReference
+	// IPv6SubnetRef is a reference to the IPAM Subnet object of the IPv6 CIDR.
+	IPv6SubnetRef *IPAMObjectReference
+}
+
+// LoadBalancerPrefix configures the reservation of a LoadBalancer prefix from an IPAM parent subnet.
+type LoadBalancerPrefix struct {
+	// ParentSubnet is the name of the IPAM Subnet in the metal namespace from which the prefix is reserved.
+	ParentSubnet string
+	// PrefixLength is the length of the reserved prefix.
+	PrefixLength int32
+}
+
+// LoadBalancerPrefixStatus contains information about the LoadBalancer prefix reserved in the metal cluster.
+type LoadBalancerPrefixStatus struct {
+	// CIDR is the reserved prefix.
+	CIDR string
+	// SubnetRef is a reference to the IPAM Subnet object of the reserved prefix.
+	SubnetRef *IPAMObjectReference
 }
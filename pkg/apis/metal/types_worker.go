@@ -5,6 +5,7 @@ package metal
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -18,10 +19,26 @@ type WorkerConfig struct {
 	ExtraIgnition *IgnitionConfig
 	// ExtraServerLabels is a map of extra labels that are applied to the ServerClaim for Server selection.
 	ExtraServerLabels map[string]string
+	// ServerSelector is a label selector which is applied to the ServerClaim for Server selection in addition to the
+	// server labels.
+	ServerSelector *metav1.LabelSelector
 	// IPAMConfig is a list of references to Network resources that should be used to assign IP addresses to the worker nodes.
 	IPAMConfig []IPAMConfig
+	// ZoneIPAMConfigs overrides the IPAMConfig for individual zones of the worker pool.
+	ZoneIPAMConfigs []ZoneIPAMConfig
 	// Metadata is a key-value map of additional data which should be passed to the Machine.
 	Metadata map[string]string
+	// ServerSettings contains the desired settings of the Servers of the worker pool.
+	ServerSettings *ServerSettings
+	// HostnamePolicy determines the hostnames of the machines of the worker pool. It overrides the hostname policy of
+	// the CloudProfile.
+	HostnamePolicy *HostnamePolicy
+	// Storage describes the local disk layout of the machines of the worker pool.
+	Storage *StorageConfig
+	// UserDataCompression is the compression of the user data and the Ignition of the machines of the worker pool.
+	UserDataCompression *UserDataCompression
+	// ReleasePolicy determines what happens to the Servers of the worker pool when their machines are deleted.
+	ReleasePolicy *ReleasePolicy
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -36,6 +53,33 @@ type WorkerStatus struct {
 	// resources that are still using this version. Hence, it stores the used versions in the provider status to ensure
 	// reconciliation is possible.
 	MachineImages []MachineImage
+	// WipedServers are the Servers claimed by the machines of worker pools with the Wipe release policy. They are
+	// tracked until they have been sanitized after their release.
+	WipedServers []WipedServer
+	// WipingPools are the names of the worker pools with the Wipe release policy at the time the Servers were last
+	// tracked, so that the Servers of worker pools which have been removed since are still tracked.
+	WipingPools []string
+	// WorkerPoolHashes are the versions of the worker pool hash used by the worker pools.
+	WorkerPoolHashes []WorkerPoolHash
+}
+
+// WorkerPoolHash records the version of the hash used for the machine classes of a worker pool.
+type WorkerPoolHash struct {
+	// Pool is the name of the worker pool.
+	Pool string
+	// Version is the version of the worker pool hash used by the worker pool.
+	Version int32
+	// CurrentHash is the hash of the worker pool in the current version at the time Version was recorded. A worker
+	// pool using an older version moves to the current version as soon as this hash changes.
+	CurrentHash string
+}
+
+// WipedServer is a Server which is wiped when the machine placed on it releases it.
+type WipedServer struct {
+	// Name is the name of the Server.
+	Name string
+	// Pool is the name of the worker pool of the machine which claimed the Server, if known.
+	Pool string
 }
 
 // MachineImage is a mapping from logical names and versions to metal-specific identifiers.
@@ -57,6 +101,168 @@ type IgnitionConfig struct {
 	Override  bool
 }
 
+// ServerSettings contains the desired hardware settings of a Server.
+type ServerSettings struct {
+	// BIOSSettings is a map of BIOS attributes and their desired values.
+	BIOSSettings map[string]string
+	// BootMode is the desired boot mode of the Server.
+	BootMode *BootMode
+	// PowerProfile is the desired power profile of the Server.
+	PowerProfile *PowerProfile
+	// MinimumFirmwareVersion is the lowest BIOS firmware version a Server has to run.
+	MinimumFirmwareVersion *string
+}
+
+// BootMode is the boot mode of a Server.
+type BootMode string
+
+const (
+	// BootModeUEFI boots the Server in UEFI mode.
+	BootModeUEFI BootMode = "UEFI"
+	// BootModeLegacy boots the Server in legacy BIOS mode.
+	BootModeLegacy BootMode = "Legacy"
+)
+
+// PowerProfile is the power profile of a Server.
+type PowerProfile string
+
+const (
+	// PowerProfilePerformance optimizes the Server for performance and low latency.
+	PowerProfilePerformance PowerProfile = "Performance"
+	// PowerProfileBalanced balances performance and power consumption.
+	PowerProfileBalanced PowerProfile = "Balanced"
+	// PowerProfilePowerSaving optimizes the Server for low power consumption.
+	PowerProfilePowerSaving PowerProfile = "PowerSaving"
+)
+
+// HostnamePolicy determines the hostname of a machine.
+type HostnamePolicy struct {
+	// Source is the source of the hostname.
+	Source HostnameSource
+	// Template is the template of the hostname if the source is Template. It may refer to {{ .MachineName }} and
+	// {{ .ServerName }}.
+	Template *string
+	// Domain is appended to the hostname to form the fully qualified domain name of the machine.
+	Domain *string
+}
+
+// HostnameSource is the source of the hostname of a machine.
+type HostnameSource string
+
+const (
+	// HostnameSourceMachineName uses the name of the Machine as hostname.
+	HostnameSourceMachineName HostnameSource = "MachineName"
+	// HostnameSourceServerName uses the name of the Server as hostname.
+	HostnameSourceServerName HostnameSource = "ServerName"
+	// HostnameSourceTemplate renders the hostname from a template.
+	HostnameSourceTemplate HostnameSource = "Template"
+)
+
+// StorageConfig describes the local disk layout of the machines of a worker pool.
+type StorageConfig struct {
+	// Disks is a list of local disks and their partitions.
+	Disks []DiskConfig
+	// RAIDs is a list of software RAID arrays built from disks or partitions.
+	RAIDs []RAIDConfig
+	// Filesystems is a list of filesystems created on disks, partitions or RAID arrays.
+	Filesystems []FilesystemConfig
+}
+
+// DiskConfig describes the partitions of a local disk.
+type DiskConfig struct {
+	// Device is the path of the disk, e.g. /dev/disk/by-path/pci-0000:01:00.0-nvme-1.
+	Device string
+	// WipeTable determines whether the partition table of the disk is wiped before it is partitioned.
+	WipeTable *bool
+	// Partitions is the list of partitions of the disk.
+	Partitions []PartitionConfig
+}
+
+// PartitionConfig describes a partition of a local disk.
+type PartitionConfig struct {
+	// Label is the label of the partition. The partition is available as /dev/disk/by-partlabel/<label>.
+	Label string
+	// Size is the size of the partition. If not set, the partition fills the remaining space of the disk.
+	Size *resource.Quantity
+}
+
+// RAIDConfig describes a software RAID array.
+type RAIDConfig struct {
+	// Name is the name of the array. The array is available as /dev/md/<name>.
+	Name string
+	// Level is the RAID level of the array.
+	Level RAIDLevel
+	// Devices is the list of disks or partitions of the array.
+	Devices []string
+	// Spares is the number of spare devices of the array.
+	Spares *int32
+}
+
+// RAIDLevel is the level of a software RAID array.
+type RAIDLevel string
+
+const (
+	// RAIDLevel0 stripes data across all devices.
+	RAIDLevel0 RAIDLevel = "raid0"
+	// RAIDLevel1 mirrors data across all devices.
+	RAIDLevel1 RAIDLevel = "raid1"
+	// RAIDLevel5 stripes data and parity across all devices.
+	RAIDLevel5 RAIDLevel = "raid5"
+	// RAIDLevel6 stripes data and double parity across all devices.
+	RAIDLevel6 RAIDLevel = "raid6"
+	// RAIDLevel10 stripes data across mirrored devices.
+	RAIDLevel10 RAIDLevel = "raid10"
+)
+
+// FilesystemConfig describes a filesystem and its mount point.
+type FilesystemConfig struct {
+	// Device is the path of the disk, partition or RAID array of the filesystem.
+	Device string
+	// Format is the format of the filesystem.
+	Format FilesystemFormat
+	// Label is the label of the filesystem.
+	Label *string
+	// MountPoint is the path the filesystem is mounted at, e.g. /var/lib/containerd.
+	MountPoint *string
+	// MountOptions are the options the filesystem is mounted with.
+	MountOptions []string
+	// WipeFilesystem determines whether an existing filesystem on the device is wiped.
+	WipeFilesystem *bool
+}
+
+// FilesystemFormat is the format of a filesystem.
+type FilesystemFormat string
+
+const (
+	// FilesystemFormatExt4 is the ext4 filesystem.
+	FilesystemFormatExt4 FilesystemFormat = "ext4"
+	// FilesystemFormatXFS is the XFS filesystem.
+	FilesystemFormatXFS FilesystemFormat = "xfs"
+)
+
+// UserDataCompression is the compression of the user data of a machine.
+type UserDataCompression string
+
+const (
+	// UserDataCompressionNone stores the user data uncompressed.
+	UserDataCompressionNone UserDataCompression = "None"
+	// UserDataCompressionGzip stores the user data gzip compressed and base64 encoded.
+	UserDataCompressionGzip UserDataCompression = "Gzip"
+)
+
+// ReleasePolicy determines what happens to a Server when the machine placed on it is deleted.
+type ReleasePolicy string
+
+const (
+	// ReleasePolicyKeep releases the Server to the pool of available Servers with its disks unchanged.
+	ReleasePolicyKeep ReleasePolicy = "Keep"
+	// ReleasePolicyWipe securely wipes the disks of the Server before it is released to the pool of available Servers.
+	ReleasePolicyWipe ReleasePolicy = "Wipe"
+	// ReleasePolicyRetain does not release the Server to the pool of available Servers, but keeps it in maintenance
+	// until an operator releases it.
+	ReleasePolicyRetain ReleasePolicy = "Retain"
+)
+
 // IPAMObjectReference is a reference to the IPAM object, which will be used for IP allocation.
 type IPAMObjectReference struct {
 	// Name is the name of resource being referenced.
@@ -74,3 +280,12 @@ type IPAMConfig struct {
 	// IPAMRef is a reference to the IPAM object, which will be used for IP allocation.
 	IPAMRef *IPAMObjectReference
 }
+
+// ZoneIPAMConfig contains the IPAM configuration of a zone of a worker pool.
+type ZoneIPAMConfig struct {
+	// Zone is the name of the zone.
+	Zone string
+	// IPAMConfig is a list of references to IPAM resources for the zone. An entry replaces the entry of the worker
+	// pool's IPAMConfig with the same metadata key.
+	IPAMConfig []IPAMConfig
+}
@@ -31,6 +31,7 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&CloudProfileConfig{},
 		&InfrastructureConfig{},
 		&InfrastructureStatus{},
+		&InfrastructureState{},
 		&ControlPlaneConfig{},
 		&WorkerConfig{},
 		&WorkerStatus{},
@@ -20,11 +20,17 @@ type CloudProfileConfig struct {
 	// RegionConfigs is the list of supported regions.
 	RegionConfigs []RegionConfig `json:"regionConfigs,omitempty"`
 	MachineTypes  []MachineType  `json:"machineTypes,omitempty"`
+	// HostnamePolicy is the default hostname policy of the machines of all Shoots using the CloudProfile.
+	// +optional
+	HostnamePolicy *HostnamePolicy `json:"hostnamePolicy,omitempty"`
 }
 
 type MachineType struct {
 	Name         string            `json:"name"`
 	ServerLabels map[string]string `json:"serverLabels,omitempty"`
+	// ServerSelector is a label selector for the Servers of the machine type in addition to the ServerLabels.
+	// +optional
+	ServerSelector *metav1.LabelSelector `json:"serverSelector,omitempty"`
 }
 
 // MachineImages is a mapping from logical names and versions to provider-specific identifiers.
@@ -43,6 +49,19 @@ type RegionConfig struct {
 	Server string `json:"server"`
 	// CertificateAuthorityData is the CA data of the region server.
 	CertificateAuthorityData []byte `json:"certificateAuthorityData"`
+	// Zones is the list of zones of this region.
+	// +optional
+	Zones []ZoneConfig `json:"zones,omitempty"`
+}
+
+// ZoneConfig is the definition of a zone within a region.
+type ZoneConfig struct {
+	// Name is the name of the zone.
+	Name string `json:"name"`
+	// ServerLabels are the labels of the servers located in this zone, e.g. a rack or room label. They are added to the
+	// server labels of the MachineClasses of this zone.
+	// +optional
+	ServerLabels map[string]string `json:"serverLabels,omitempty"`
 }
 
 // MachineImageVersion contains a version and a provider-specific identifier.
@@ -18,6 +18,19 @@ type InfrastructureConfig struct {
 	// Networks is the metal specific network configuration.
 	// +optional
 	Networks []Networks `json:"networks,omitempty"`
+	// LoadBalancerPrefix configures the reservation of a LoadBalancer prefix from an IPAM parent subnet.
+	// +optional
+	LoadBalancerPrefix *LoadBalancerPrefix `json:"loadBalancerPrefix,omitempty"`
+	// IPv6PodsCIDR is the IPv6 pod network of a dual-stack shoot, whose spec.networking.pods is the IPv4 pod network.
+	// +optional
+	IPv6PodsCIDR string `json:"ipv6PodsCIDR,omitempty"`
+	// IPv6ServicesCIDR is the IPv6 service network of a dual-stack shoot, whose spec.networking.services is the IPv4
+	// service network.
+	// +optional
+	IPv6ServicesCIDR string `json:"ipv6ServicesCIDR,omitempty"`
+	// IPv6LoadBalancerPrefix configures the reservation of an IPv6 LoadBalancer prefix of a dual-stack shoot.
+	// +optional
+	IPv6LoadBalancerPrefix *LoadBalancerPrefix `json:"ipv6LoadBalancerPrefix,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -25,15 +38,106 @@ type InfrastructureConfig struct {
 // InfrastructureStatus contains information about created infrastructure resources.
 type InfrastructureStatus struct {
 	metav1.TypeMeta `json:",inline"`
+
+	// Networks contains information about the networks provisioned in the metal cluster.
+	// +optional
+	Networks []NetworkStatus `json:"networks,omitempty"`
+	// LoadBalancerPrefix contains information about the LoadBalancer prefix reserved in the metal cluster.
+	// +optional
+	LoadBalancerPrefix *LoadBalancerPrefixStatus `json:"loadBalancerPrefix,omitempty"`
+	// IPv6LoadBalancerPrefix contains information about the IPv6 LoadBalancer prefix reserved in the metal cluster.
+	// +optional
+	IPv6LoadBalancerPrefix *LoadBalancerPrefixStatus `json:"ipv6LoadBalancerPrefix,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// InfrastructureState contains the state of the infrastructure resources in the metal cluster. It is persisted
+// in the Infrastructure during control plane migration to adopt the resources on the destination seed.
+type InfrastructureState struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Networks contains the networks which have been provisioned in the metal cluster.
+	// +optional
+	Networks []NetworkStatus `json:"networks,omitempty"`
+	// LoadBalancerPrefix contains the LoadBalancer prefix which has been reserved in the metal cluster.
+	// +optional
+	LoadBalancerPrefix *LoadBalancerPrefixStatus `json:"loadBalancerPrefix,omitempty"`
+	// IPv6LoadBalancerPrefix contains the IPv6 LoadBalancer prefix which has been reserved in the metal cluster.
+	// +optional
+	IPv6LoadBalancerPrefix *LoadBalancerPrefixStatus `json:"ipv6LoadBalancerPrefix,omitempty"`
 }
 
 // Networks holds information about the Kubernetes and infrastructure networks.
 type Networks struct {
 	// Name is the name for this CIDR.
 	Name string `json:"name"`
-	// CIDR is the workers subnet range to create.
-	CIDR string `json:"cidr"`
+	// CIDR is the IPv4 workers subnet range to create. It is required if the shoot uses the IPv4 family.
+	// +optional
+	CIDR string `json:"cidr,omitempty"`
+	// IPv6CIDR is the IPv6 workers subnet range to create. It is required if the shoot uses the IPv6 family.
+	// +optional
+	IPv6CIDR string `json:"ipv6CIDR,omitempty"`
 	// ID is the ID for the workers' subnet.
 	// +optional
 	ID string `json:"id,omitempty"`
+	// Gateway is the IPv4 gateway address of the network. Defaults to the first address of the CIDR.
+	// +optional
+	Gateway string `json:"gateway,omitempty"`
+	// IPv6Gateway is the IPv6 gateway address of the network. Defaults to the first address of the IPv6CIDR.
+	// +optional
+	IPv6Gateway string `json:"ipv6Gateway,omitempty"`
+	// DNSResolvers is a list of DNS resolver addresses of the network.
+	// +optional
+	DNSResolvers []string `json:"dnsResolvers,omitempty"`
+}
+
+// NetworkStatus contains information about a network provisioned in the metal cluster.
+type NetworkStatus struct {
+	// Name is the name of the network.
+	Name string `json:"name"`
+	// ID is the resolved ID of the network.
+	// +optional
+	ID string `json:"id,omitempty"`
+	// CIDR is the CIDR of the network's IPv4 subnet.
+	// +optional
+	CIDR string `json:"cidr,omitempty"`
+	// IPv6CIDR is the CIDR of the network's IPv6 subnet.
+	// +optional
+	IPv6CIDR string `json:"ipv6CIDR,omitempty"`
+	// Gateway is the IPv4 gateway address of the network.
+	// +optional
+	Gateway string `json:"gateway,omitempty"`
+	// IPv6Gateway is the IPv6 gateway address of the network.
+	// +optional
+	IPv6Gateway string `json:"ipv6Gateway,omitempty"`
+	// DNSResolvers is a list of DNS resolver addresses of the network.
+	// +optional
+	DNSResolvers []string `json:"dnsResolvers,omitempty"`
+	// NetworkRef is a reference to the IPAM Network object.
+	// +optional
+	NetworkRef *IPAMObjectReference `json:"networkRef,omitempty"`
+	// SubnetRef is a reference to the IPAM Subnet object of the IPv4 CIDR.
+	// +optional
+	SubnetRef *IPAMObjectReference `json:"subnetRef,omitempty"`
+	// IPv6SubnetRef is a reference to the IPAM Subnet object of the IPv6 CIDR.
+	// +optional
+	IPv6SubnetRef *IPAMObjectReference `json:"ipv6SubnetRef,omitempty"`
+}
+
+// LoadBalancerPrefix configures the reservation of a LoadBalancer prefix from an IPAM parent subnet.
+type LoadBalancerPrefix struct {
+	// ParentSubnet is the name of the IPAM Subnet in the metal namespace from which the prefix is reserved.
+	ParentSubnet string `json:"parentSubnet"`
+	// PrefixLength is the length of the reserved prefix.
+	PrefixLength int32 `json:"prefixLength"`
+}
+
+// LoadBalancerPrefixStatus contains information about the LoadBalancer prefix reserved in the metal cluster.
+type LoadBalancerPrefixStatus struct {
+	// CIDR is the reserved prefix.
+	CIDR string `json:"cidr"`
+	// SubnetRef is a reference to the IPAM Subnet object of the reserved prefix.
+	// +optional
+	SubnetRef *IPAMObjectReference `json:"subnetRef,omitempty"`
 }
@@ -5,6 +5,7 @@ package v1alpha1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -19,12 +20,35 @@ type WorkerConfig struct {
 	// ExtraServerLabels is a map of additional labels that are applied to the ServerClaim for Server selection.
 	// +optional
 	ExtraServerLabels map[string]string `json:"extraServerLabels,omitempty"`
+	// ServerSelector is a label selector which is applied to the ServerClaim for Server selection in addition to the
+	// server labels.
+	// +optional
+	ServerSelector *metav1.LabelSelector `json:"serverSelector,omitempty"`
 	// IPAMConfig is a list of references to Network resources that should be used to assign IP addresses to the worker nodes.
 	// +optional
 	IPAMConfig []IPAMConfig `json:"ipamConfig,omitempty"`
+	// ZoneIPAMConfigs overrides the IPAMConfig for individual zones of the worker pool.
+	// +optional
+	ZoneIPAMConfigs []ZoneIPAMConfig `json:"zoneIPAMConfigs,omitempty"`
 	// Metadata is a key-value map of additional data which should be passed to the Machine.
 	// +optional
 	Metadata map[string]string `json:"metadata,omitempty"`
+	// ServerSettings contains the desired settings of the Servers of the worker pool.
+	// +optional
+	ServerSettings *ServerSettings `json:"serverSettings,omitempty"`
+	// HostnamePolicy determines the hostnames of the machines of the worker pool. It overrides the hostname policy of
+	// the CloudProfile.
+	// +optional
+	HostnamePolicy *HostnamePolicy `json:"hostnamePolicy,omitempty"`
+	// Storage describes the local disk layout of the machines of the worker pool.
+	// +optional
+	Storage *StorageConfig `json:"storage,omitempty"`
+	// UserDataCompression is the compression of the user data and the Ignition of the machines of the worker pool.
+	// +optional
+	UserDataCompression *UserDataCompression `json:"userDataCompression,omitempty"`
+	// ReleasePolicy determines what happens to the Servers of the worker pool when their machines are deleted.
+	// +optional
+	ReleasePolicy *ReleasePolicy `json:"releasePolicy,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -40,6 +64,37 @@ type WorkerStatus struct {
 	// reconciliation is possible.
 	// +optional
 	MachineImages []MachineImage `json:"machineImages,omitempty"`
+	// WipedServers are the Servers claimed by the machines of worker pools with the Wipe release policy. They are
+	// tracked until they have been sanitized after their release.
+	// +optional
+	WipedServers []WipedServer `json:"wipedServers,omitempty"`
+	// WipingPools are the names of the worker pools with the Wipe release policy at the time the Servers were last
+	// tracked, so that the Servers of worker pools which have been removed since are still tracked.
+	// +optional
+	WipingPools []string `json:"wipingPools,omitempty"`
+	// WorkerPoolHashes are the versions of the worker pool hash used by the worker pools.
+	// +optional
+	WorkerPoolHashes []WorkerPoolHash `json:"workerPoolHashes,omitempty"`
+}
+
+// WorkerPoolHash records the version of the hash used for the machine classes of a worker pool.
+type WorkerPoolHash struct {
+	// Pool is the name of the worker pool.
+	Pool string `json:"pool"`
+	// Version is the version of the worker pool hash used by the worker pool.
+	Version int32 `json:"version"`
+	// CurrentHash is the hash of the worker pool in the current version at the time Version was recorded. A worker
+	// pool using an older version moves to the current version as soon as this hash changes.
+	CurrentHash string `json:"currentHash"`
+}
+
+// WipedServer is a Server which is wiped when the machine placed on it releases it.
+type WipedServer struct {
+	// Name is the name of the Server.
+	Name string `json:"name"`
+	// Pool is the name of the worker pool of the machine which claimed the Server, if known.
+	// +optional
+	Pool string `json:"pool,omitempty"`
 }
 
 // MachineImage is a mapping from logical names and versions to metal-specific identifiers.
@@ -71,6 +126,185 @@ type IgnitionConfig struct {
 	Override bool `json:"override,omitempty"`
 }
 
+// ServerSettings contains the desired hardware settings of a Server.
+type ServerSettings struct {
+	// BIOSSettings is a map of BIOS attributes and their desired values.
+	// +optional
+	BIOSSettings map[string]string `json:"biosSettings,omitempty"`
+	// BootMode is the desired boot mode of the Server.
+	// +optional
+	BootMode *BootMode `json:"bootMode,omitempty"`
+	// PowerProfile is the desired power profile of the Server.
+	// +optional
+	PowerProfile *PowerProfile `json:"powerProfile,omitempty"`
+	// MinimumFirmwareVersion is the lowest BIOS firmware version a Server has to run.
+	// +optional
+	MinimumFirmwareVersion *string `json:"minimumFirmwareVersion,omitempty"`
+}
+
+// BootMode is the boot mode of a Server.
+type BootMode string
+
+const (
+	// BootModeUEFI boots the Server in UEFI mode.
+	BootModeUEFI BootMode = "UEFI"
+	// BootModeLegacy boots the Server in legacy BIOS mode.
+	BootModeLegacy BootMode = "Legacy"
+)
+
+// PowerProfile is the power profile of a Server.
+type PowerProfile string
+
+const (
+	// PowerProfilePerformance optimizes the Server for performance and low latency.
+	PowerProfilePerformance PowerProfile = "Performance"
+	// PowerProfileBalanced balances performance and power consumption.
+	PowerProfileBalanced PowerProfile = "Balanced"
+	// PowerProfilePowerSaving optimizes the Server for low power consumption.
+	PowerProfilePowerSaving PowerProfile = "PowerSaving"
+)
+
+// HostnamePolicy determines the hostname of a machine.
+type HostnamePolicy struct {
+	// Source is the source of the hostname.
+	Source HostnameSource `json:"source"`
+	// Template is the template of the hostname if the source is Template. It may refer to {{ .MachineName }} and
+	// {{ .ServerName }}.
+	// +optional
+	Template *string `json:"template,omitempty"`
+	// Domain is appended to the hostname to form the fully qualified domain name of the machine.
+	// +optional
+	Domain *string `json:"domain,omitempty"`
+}
+
+// HostnameSource is the source of the hostname of a machine.
+type HostnameSource string
+
+const (
+	// HostnameSourceMachineName uses the name of the Machine as hostname.
+	HostnameSourceMachineName HostnameSource = "MachineName"
+	// HostnameSourceServerName uses the name of the Server as hostname.
+	HostnameSourceServerName HostnameSource = "ServerName"
+	// HostnameSourceTemplate renders the hostname from a template.
+	HostnameSourceTemplate HostnameSource = "Template"
+)
+
+// StorageConfig describes the local disk layout of the machines of a worker pool.
+type StorageConfig struct {
+	// Disks is a list of local disks and their partitions.
+	// +optional
+	Disks []DiskConfig `json:"disks,omitempty"`
+	// RAIDs is a list of software RAID arrays built from disks or partitions.
+	// +optional
+	RAIDs []RAIDConfig `json:"raids,omitempty"`
+	// Filesystems is a list of filesystems created on disks, partitions or RAID arrays.
+	// +optional
+	Filesystems []FilesystemConfig `json:"filesystems,omitempty"`
+}
+
+// DiskConfig describes the partitions of a local disk.
+type DiskConfig struct {
+	// Device is the path of the disk, e.g. /dev/disk/by-path/pci-0000:01:00.0-nvme-1.
+	Device string `json:"device"`
+	// WipeTable determines whether the partition table of the disk is wiped before it is partitioned.
+	// +optional
+	WipeTable *bool `json:"wipeTable,omitempty"`
+	// Partitions is the list of partitions of the disk.
+	// +optional
+	Partitions []PartitionConfig `json:"partitions,omitempty"`
+}
+
+// PartitionConfig describes a partition of a local disk.
+type PartitionConfig struct {
+	// Label is the label of the partition. The partition is available as /dev/disk/by-partlabel/<label>.
+	Label string `json:"label"`
+	// Size is the size of the partition. If not set, the partition fills the remaining space of the disk.
+	// +optional
+	Size *resource.Quantity `json:"size,omitempty"`
+}
+
+// RAIDConfig describes a software RAID array.
+type RAIDConfig struct {
+	// Name is the name of the array. The array is available as /dev/md/<name>.
+	Name string `json:"name"`
+	// Level is the RAID level of the array.
+	Level RAIDLevel `json:"level"`
+	// Devices is the list of disks or partitions of the array.
+	Devices []string `json:"devices"`
+	// Spares is the number of spare devices of the array.
+	// +optional
+	Spares *int32 `json:"spares,omitempty"`
+}
+
+// RAIDLevel is the level of a software RAID array.
+type RAIDLevel string
+
+const (
+	// RAIDLevel0 stripes data across all devices.
+	RAIDLevel0 RAIDLevel = "raid0"
+	// RAIDLevel1 mirrors data across all devices.
+	RAIDLevel1 RAIDLevel = "raid1"
+	// RAIDLevel5 stripes data and parity across all devices.
+	RAIDLevel5 RAIDLevel = "raid5"
+	// RAIDLevel6 stripes data and double parity across all devices.
+	RAIDLevel6 RAIDLevel = "raid6"
+	// RAIDLevel10 stripes data across mirrored devices.
+	RAIDLevel10 RAIDLevel = "raid10"
+)
+
+// FilesystemConfig describes a filesystem and its mount point.
+type FilesystemConfig struct {
+	// Device is the path of the disk, partition or RAID array of the filesystem.
+	Device string `json:"device"`
+	// Format is the format of the filesystem.
+	Format FilesystemFormat `json:"format"`
+	// Label is the label of the filesystem.
+	// +optional
+	Label *string `json:"label,omitempty"`
+	// MountPoint is the path the filesystem is mounted at, e.g. /var/lib/containerd.
+	// +optional
+	MountPoint *string `json:"mountPoint,omitempty"`
+	// MountOptions are the options the filesystem is mounted with.
+	// +optional
+	MountOptions []string `json:"mountOptions,omitempty"`
+	// WipeFilesystem determines whether an existing filesystem on the device is wiped.
+	// +optional
+	WipeFilesystem *bool `json:"wipeFilesystem,omitempty"`
+}
+
+// FilesystemFormat is the format of a filesystem.
+type FilesystemFormat string
+
+const (
+	// FilesystemFormatExt4 is the ext4 filesystem.
+	FilesystemFormatExt4 FilesystemFormat = "ext4"
+	// FilesystemFormatXFS is the XFS filesystem.
+	FilesystemFormatXFS FilesystemFormat = "xfs"
+)
+
+// UserDataCompression is the compression of the user data of a machine.
+type UserDataCompression string
+
+const (
+	// UserDataCompressionNone stores the user data uncompressed.
+	UserDataCompressionNone UserDataCompression = "None"
+	// UserDataCompressionGzip stores the user data gzip compressed and base64 encoded.
+	UserDataCompressionGzip UserDataCompression = "Gzip"
+)
+
+// ReleasePolicy determines what happens to a Server when the machine placed on it is deleted.
+type ReleasePolicy string
+
+const (
+	// ReleasePolicyKeep releases the Server to the pool of available Servers with its disks unchanged.
+	ReleasePolicyKeep ReleasePolicy = "Keep"
+	// ReleasePolicyWipe securely wipes the disks of the Server before it is released to the pool of available Servers.
+	ReleasePolicyWipe ReleasePolicy = "Wipe"
+	// ReleasePolicyRetain does not release the Server to the pool of available Servers, but keeps it in maintenance
+	// until an operator releases it.
+	ReleasePolicyRetain ReleasePolicy = "Retain"
+)
+
 // IPAMObjectReference is a reference to the IPAM object, which will be used for IP allocation.
 type IPAMObjectReference struct {
 	// Name is the name of resource being referenced.
@@ -88,3 +322,12 @@ type IPAMConfig struct {
 	// IPAMRef is a reference to the IPAM object, which will be used for IP allocation.
 	IPAMRef *IPAMObjectReference `json:"ipamRef"`
 }
+
+// ZoneIPAMConfig contains the IPAM configuration of a zone of a worker pool.
+type ZoneIPAMConfig struct {
+	// Zone is the name of the zone.
+	Zone string `json:"zone"`
+	// IPAMConfig is a list of references to IPAM resources for the zone. An entry replaces the entry of the worker
+	// pool's IPAMConfig with the same metadata key.
+	IPAMConfig []IPAMConfig `json:"ipamConfig"`
+}
@@ -14,6 +14,8 @@ import (
 
 	metal "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal"
 	v1 "k8s.io/api/core/v1"
+	resource "k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	conversion "k8s.io/apimachinery/pkg/conversion"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
@@ -105,6 +107,36 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*DiskConfig)(nil), (*metal.DiskConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_DiskConfig_To_metal_DiskConfig(a.(*DiskConfig), b.(*metal.DiskConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*metal.DiskConfig)(nil), (*DiskConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_metal_DiskConfig_To_v1alpha1_DiskConfig(a.(*metal.DiskConfig), b.(*DiskConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*FilesystemConfig)(nil), (*metal.FilesystemConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_FilesystemConfig_To_metal_FilesystemConfig(a.(*FilesystemConfig), b.(*metal.FilesystemConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*metal.FilesystemConfig)(nil), (*FilesystemConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_metal_FilesystemConfig_To_v1alpha1_FilesystemConfig(a.(*metal.FilesystemConfig), b.(*FilesystemConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*HostnamePolicy)(nil), (*metal.HostnamePolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_HostnamePolicy_To_metal_HostnamePolicy(a.(*HostnamePolicy), b.(*metal.HostnamePolicy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*metal.HostnamePolicy)(nil), (*HostnamePolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_metal_HostnamePolicy_To_v1alpha1_HostnamePolicy(a.(*metal.HostnamePolicy), b.(*HostnamePolicy), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*IPAMConfig)(nil), (*metal.IPAMConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_IPAMConfig_To_metal_IPAMConfig(a.(*IPAMConfig), b.(*metal.IPAMConfig), scope)
 	}); err != nil {
@@ -155,6 +187,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*InfrastructureState)(nil), (*metal.InfrastructureState)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_InfrastructureState_To_metal_InfrastructureState(a.(*InfrastructureState), b.(*metal.InfrastructureState), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*metal.InfrastructureState)(nil), (*InfrastructureState)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_metal_InfrastructureState_To_v1alpha1_InfrastructureState(a.(*metal.InfrastructureState), b.(*InfrastructureState), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*InfrastructureStatus)(nil), (*metal.InfrastructureStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_InfrastructureStatus_To_metal_InfrastructureStatus(a.(*InfrastructureStatus), b.(*metal.InfrastructureStatus), scope)
 	}); err != nil {
@@ -175,6 +217,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*LoadBalancerPrefix)(nil), (*metal.LoadBalancerPrefix)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_LoadBalancerPrefix_To_metal_LoadBalancerPrefix(a.(*LoadBalancerPrefix), b.(*metal.LoadBalancerPrefix), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*metal.LoadBalancerPrefix)(nil), (*LoadBalancerPrefix)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_metal_LoadBalancerPrefix_To_v1alpha1_LoadBalancerPrefix(a.(*metal.LoadBalancerPrefix), b.(*LoadBalancerPrefix), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*LoadBalancerPrefixStatus)(nil), (*metal.LoadBalancerPrefixStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_LoadBalancerPrefixStatus_To_metal_LoadBalancerPrefixStatus(a.(*LoadBalancerPrefixStatus), b.(*metal.LoadBalancerPrefixStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*metal.LoadBalancerPrefixStatus)(nil), (*LoadBalancerPrefixStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_metal_LoadBalancerPrefixStatus_To_v1alpha1_LoadBalancerPrefixStatus(a.(*metal.LoadBalancerPrefixStatus), b.(*LoadBalancerPrefixStatus), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*MachineImage)(nil), (*metal.MachineImage)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_MachineImage_To_metal_MachineImage(a.(*MachineImage), b.(*metal.MachineImage), scope)
 	}); err != nil {
@@ -225,6 +287,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*NetworkStatus)(nil), (*metal.NetworkStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_NetworkStatus_To_metal_NetworkStatus(a.(*NetworkStatus), b.(*metal.NetworkStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*metal.NetworkStatus)(nil), (*NetworkStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_metal_NetworkStatus_To_v1alpha1_NetworkStatus(a.(*metal.NetworkStatus), b.(*NetworkStatus), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*Networks)(nil), (*metal.Networks)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_Networks_To_metal_Networks(a.(*Networks), b.(*metal.Networks), scope)
 	}); err != nil {
@@ -235,6 +307,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*PartitionConfig)(nil), (*metal.PartitionConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_PartitionConfig_To_metal_PartitionConfig(a.(*PartitionConfig), b.(*metal.PartitionConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*metal.PartitionConfig)(nil), (*PartitionConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_metal_PartitionConfig_To_v1alpha1_PartitionConfig(a.(*metal.PartitionConfig), b.(*PartitionConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*RAIDConfig)(nil), (*metal.RAIDConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_RAIDConfig_To_metal_RAIDConfig(a.(*RAIDConfig), b.(*metal.RAIDConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*metal.RAIDConfig)(nil), (*RAIDConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_metal_RAIDConfig_To_v1alpha1_RAIDConfig(a.(*metal.RAIDConfig), b.(*RAIDConfig), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*RegionConfig)(nil), (*metal.RegionConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_RegionConfig_To_metal_RegionConfig(a.(*RegionConfig), b.(*metal.RegionConfig), scope)
 	}); err != nil {
@@ -245,6 +337,36 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ServerSettings)(nil), (*metal.ServerSettings)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ServerSettings_To_metal_ServerSettings(a.(*ServerSettings), b.(*metal.ServerSettings), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*metal.ServerSettings)(nil), (*ServerSettings)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_metal_ServerSettings_To_v1alpha1_ServerSettings(a.(*metal.ServerSettings), b.(*ServerSettings), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*StorageConfig)(nil), (*metal.StorageConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_StorageConfig_To_metal_StorageConfig(a.(*StorageConfig), b.(*metal.StorageConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*metal.StorageConfig)(nil), (*StorageConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_metal_StorageConfig_To_v1alpha1_StorageConfig(a.(*metal.StorageConfig), b.(*StorageConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*WipedServer)(nil), (*metal.WipedServer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_WipedServer_To_metal_WipedServer(a.(*WipedServer), b.(*metal.WipedServer), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*metal.WipedServer)(nil), (*WipedServer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_metal_WipedServer_To_v1alpha1_WipedServer(a.(*metal.WipedServer), b.(*WipedServer), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*WorkerConfig)(nil), (*metal.WorkerConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_WorkerConfig_To_metal_WorkerConfig(a.(*WorkerConfig), b.(*metal.WorkerConfig), scope)
 	}); err != nil {
@@ -255,6 +377,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*WorkerPoolHash)(nil), (*metal.WorkerPoolHash)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_WorkerPoolHash_To_metal_WorkerPoolHash(a.(*WorkerPoolHash), b.(*metal.WorkerPoolHash), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*metal.WorkerPoolHash)(nil), (*WorkerPoolHash)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_metal_WorkerPoolHash_To_v1alpha1_WorkerPoolHash(a.(*metal.WorkerPoolHash), b.(*WorkerPoolHash), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*WorkerStatus)(nil), (*metal.WorkerStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_WorkerStatus_To_metal_WorkerStatus(a.(*WorkerStatus), b.(*metal.WorkerStatus), scope)
 	}); err != nil {
@@ -265,6 +397,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ZoneConfig)(nil), (*metal.ZoneConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ZoneConfig_To_metal_ZoneConfig(a.(*ZoneConfig), b.(*metal.ZoneConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*metal.ZoneConfig)(nil), (*ZoneConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_metal_ZoneConfig_To_v1alpha1_ZoneConfig(a.(*metal.ZoneConfig), b.(*ZoneConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ZoneIPAMConfig)(nil), (*metal.ZoneIPAMConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ZoneIPAMConfig_To_metal_ZoneIPAMConfig(a.(*ZoneIPAMConfig), b.(*metal.ZoneIPAMConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*metal.ZoneIPAMConfig)(nil), (*ZoneIPAMConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_metal_ZoneIPAMConfig_To_v1alpha1_ZoneIPAMConfig(a.(*metal.ZoneIPAMConfig), b.(*ZoneIPAMConfig), scope)
+	}); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -424,6 +576,7 @@ func autoConvert_v1alpha1_CloudProfileConfig_To_metal_CloudProfileConfig(in *Clo
 	out.MachineImages = *(*[]metal.MachineImages)(unsafe.Pointer(&in.MachineImages))
 	out.RegionConfigs = *(*[]metal.RegionConfig)(unsafe.Pointer(&in.RegionConfigs))
 	out.MachineTypes = *(*[]metal.MachineType)(unsafe.Pointer(&in.MachineTypes))
+	out.HostnamePolicy = (*metal.HostnamePolicy)(unsafe.Pointer(in.HostnamePolicy))
 	return nil
 }
 
@@ -436,6 +589,7 @@ func autoConvert_metal_CloudProfileConfig_To_v1alpha1_CloudProfileConfig(in *met
 	out.MachineImages = *(*[]MachineImages)(unsafe.Pointer(&in.MachineImages))
 	out.RegionConfigs = *(*[]RegionConfig)(unsafe.Pointer(&in.RegionConfigs))
 	out.MachineTypes = *(*[]MachineType)(unsafe.Pointer(&in.MachineTypes))
+	out.HostnamePolicy = (*HostnamePolicy)(unsafe.Pointer(in.HostnamePolicy))
 	return nil
 }
 
@@ -466,6 +620,84 @@ func Convert_metal_ControlPlaneConfig_To_v1alpha1_ControlPlaneConfig(in *metal.C
 	return autoConvert_metal_ControlPlaneConfig_To_v1alpha1_ControlPlaneConfig(in, out, s)
 }
 
+func autoConvert_v1alpha1_DiskConfig_To_metal_DiskConfig(in *DiskConfig, out *metal.DiskConfig, s conversion.Scope) error {
+	out.Device = in.Device
+	out.WipeTable = (*bool)(unsafe.Pointer(in.WipeTable))
+	out.Partitions = *(*[]metal.PartitionConfig)(unsafe.Pointer(&in.Partitions))
+	return nil
+}
+
+// Convert_v1alpha1_DiskConfig_To_metal_DiskConfig is an autogenerated conversion function.
+func Convert_v1alpha1_DiskConfig_To_metal_DiskConfig(in *DiskConfig, out *metal.DiskConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha1_DiskConfig_To_metal_DiskConfig(in, out, s)
+}
+
+func autoConvert_metal_DiskConfig_To_v1alpha1_DiskConfig(in *metal.DiskConfig, out *DiskConfig, s conversion.Scope) error {
+	out.Device = in.Device
+	out.WipeTable = (*bool)(unsafe.Pointer(in.WipeTable))
+	out.Partitions = *(*[]PartitionConfig)(unsafe.Pointer(&in.Partitions))
+	return nil
+}
+
+// Convert_metal_DiskConfig_To_v1alpha1_DiskConfig is an autogenerated conversion function.
+func Convert_metal_DiskConfig_To_v1alpha1_DiskConfig(in *metal.DiskConfig, out *DiskConfig, s conversion.Scope) error {
+	return autoConvert_metal_DiskConfig_To_v1alpha1_DiskConfig(in, out, s)
+}
+
+func autoConvert_v1alpha1_FilesystemConfig_To_metal_FilesystemConfig(in *FilesystemConfig, out *metal.FilesystemConfig, s conversion.Scope) error {
+	out.Device = in.Device
+	out.Format = metal.FilesystemFormat(in.Format)
+	out.Label = (*string)(unsafe.Pointer(in.Label))
+	out.MountPoint = (*string)(unsafe.Pointer(in.MountPoint))
+	out.MountOptions = *(*[]string)(unsafe.Pointer(&in.MountOptions))
+	out.WipeFilesystem = (*bool)(unsafe.Pointer(in.WipeFilesystem))
+	return nil
+}
+
+// Convert_v1alpha1_FilesystemConfig_To_metal_FilesystemConfig is an autogenerated conversion function.
+func Convert_v1alpha1_FilesystemConfig_To_metal_FilesystemConfig(in *FilesystemConfig, out *metal.FilesystemConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha1_FilesystemConfig_To_metal_FilesystemConfig(in, out, s)
+}
+
+func autoConvert_metal_FilesystemConfig_To_v1alpha1_FilesystemConfig(in *metal.FilesystemConfig, out *FilesystemConfig, s conversion.Scope) error {
+	out.Device = in.Device
+	out.Format = FilesystemFormat(in.Format)
+	out.Label = (*string)(unsafe.Pointer(in.Label))
+	out.MountPoint = (*string)(unsafe.Pointer(in.MountPoint))
+	out.MountOptions = *(*[]string)(unsafe.Pointer(&in.MountOptions))
+	out.WipeFilesystem = (*bool)(unsafe.Pointer(in.WipeFilesystem))
+	return nil
+}
+
+// Convert_metal_FilesystemConfig_To_v1alpha1_FilesystemConfig is an autogenerated conversion function.
+func Convert_metal_FilesystemConfig_To_v1alpha1_FilesystemConfig(in *metal.FilesystemConfig, out *FilesystemConfig, s conversion.Scope) error {
+	return autoConvert_metal_FilesystemConfig_To_v1alpha1_FilesystemConfig(in, out, s)
+}
+
+func autoConvert_v1alpha1_HostnamePolicy_To_metal_HostnamePolicy(in *HostnamePolicy, out *metal.HostnamePolicy, s conversion.Scope) error {
+	out.Source = metal.HostnameSource(in.Source)
+	out.Template = (*string)(unsafe.Pointer(in.Template))
+	out.Domain = (*string)(unsafe.Pointer(in.Domain))
+	return nil
+}
+
+// Convert_v1alpha1_HostnamePolicy_To_metal_HostnamePolicy is an autogenerated conversion function.
+func Convert_v1alpha1_HostnamePolicy_To_metal_HostnamePolicy(in *HostnamePolicy, out *metal.HostnamePolicy, s conversion.Scope) error {
+	return autoConvert_v1alpha1_HostnamePolicy_To_metal_HostnamePolicy(in, out, s)
+}
+
+func autoConvert_metal_HostnamePolicy_To_v1alpha1_HostnamePolicy(in *metal.HostnamePolicy, out *HostnamePolicy, s conversion.Scope) error {
+	out.Source = HostnameSource(in.Source)
+	out.Template = (*string)(unsafe.Pointer(in.Template))
+	out.Domain = (*string)(unsafe.Pointer(in.Domain))
+	return nil
+}
+
+// Convert_metal_HostnamePolicy_To_v1alpha1_HostnamePolicy is an autogenerated conversion function.
+func Convert_metal_HostnamePolicy_To_v1alpha1_HostnamePolicy(in *metal.HostnamePolicy, out *HostnamePolicy, s conversion.Scope) error {
+	return autoConvert_metal_HostnamePolicy_To_v1alpha1_HostnamePolicy(in, out, s)
+}
+
 func autoConvert_v1alpha1_IPAMConfig_To_metal_IPAMConfig(in *IPAMConfig, out *metal.IPAMConfig, s conversion.Scope) error {
 	out.MetadataKey = in.MetadataKey
 	out.IPAMRef = (*metal.IPAMObjectReference)(unsafe.Pointer(in.IPAMRef))
@@ -560,6 +792,10 @@ func Convert_metal_IgnitionConfig_To_v1alpha1_IgnitionConfig(in *metal.IgnitionC
 
 func autoConvert_v1alpha1_InfrastructureConfig_To_metal_InfrastructureConfig(in *InfrastructureConfig, out *metal.InfrastructureConfig, s conversion.Scope) error {
 	out.Networks = *(*[]metal.Networks)(unsafe.Pointer(&in.Networks))
+	out.LoadBalancerPrefix = (*metal.LoadBalancerPrefix)(unsafe.Pointer(in.LoadBalancerPrefix))
+	out.IPv6PodsCIDR = in.IPv6PodsCIDR
+	out.IPv6ServicesCIDR = in.IPv6ServicesCIDR
+	out.IPv6LoadBalancerPrefix = (*metal.LoadBalancerPrefix)(unsafe.Pointer(in.IPv6LoadBalancerPrefix))
 	return nil
 }
 
@@ -570,6 +806,10 @@ func Convert_v1alpha1_InfrastructureConfig_To_metal_InfrastructureConfig(in *Inf
 
 func autoConvert_metal_InfrastructureConfig_To_v1alpha1_InfrastructureConfig(in *metal.InfrastructureConfig, out *InfrastructureConfig, s conversion.Scope) error {
 	out.Networks = *(*[]Networks)(unsafe.Pointer(&in.Networks))
+	out.LoadBalancerPrefix = (*LoadBalancerPrefix)(unsafe.Pointer(in.LoadBalancerPrefix))
+	out.IPv6PodsCIDR = in.IPv6PodsCIDR
+	out.IPv6ServicesCIDR = in.IPv6ServicesCIDR
+	out.IPv6LoadBalancerPrefix = (*LoadBalancerPrefix)(unsafe.Pointer(in.IPv6LoadBalancerPrefix))
 	return nil
 }
 
@@ -578,7 +818,34 @@ func Convert_metal_InfrastructureConfig_To_v1alpha1_InfrastructureConfig(in *met
 	return autoConvert_metal_InfrastructureConfig_To_v1alpha1_InfrastructureConfig(in, out, s)
 }
 
+func autoConvert_v1alpha1_InfrastructureState_To_metal_InfrastructureState(in *InfrastructureState, out *metal.InfrastructureState, s conversion.Scope) error {
+	out.Networks = *(*[]metal.NetworkStatus)(unsafe.Pointer(&in.Networks))
+	out.LoadBalancerPrefix = (*metal.LoadBalancerPrefixStatus)(unsafe.Pointer(in.LoadBalancerPrefix))
+	out.IPv6LoadBalancerPrefix = (*metal.LoadBalancerPrefixStatus)(unsafe.Pointer(in.IPv6LoadBalancerPrefix))
+	return nil
+}
+
+// Convert_v1alpha1_InfrastructureState_To_metal_InfrastructureState is an autogenerated conversion function.
+func Convert_v1alpha1_InfrastructureState_To_metal_InfrastructureState(in *InfrastructureState, out *metal.InfrastructureState, s conversion.Scope) error {
+	return autoConvert_v1alpha1_InfrastructureState_To_metal_InfrastructureState(in, out, s)
+}
+
+func autoConvert_metal_InfrastructureState_To_v1alpha1_InfrastructureState(in *metal.InfrastructureState, out *InfrastructureState, s conversion.Scope) error {
+	out.Networks = *(*[]NetworkStatus)(unsafe.Pointer(&in.Networks))
+	out.LoadBalancerPrefix = (*LoadBalancerPrefixStatus)(unsafe.Pointer(in.LoadBalancerPrefix))
+	out.IPv6LoadBalancerPrefix = (*LoadBalancerPrefixStatus)(unsafe.Pointer(in.IPv6LoadBalancerPrefix))
+	return nil
+}
+
+// Convert_metal_InfrastructureState_To_v1alpha1_InfrastructureState is an autogenerated conversion function.
+func Convert_metal_InfrastructureState_To_v1alpha1_InfrastructureState(in *metal.InfrastructureState, out *InfrastructureState, s conversion.Scope) error {
+	return autoConvert_metal_InfrastructureState_To_v1alpha1_InfrastructureState(in, out, s)
+}
+
 func autoConvert_v1alpha1_InfrastructureStatus_To_metal_InfrastructureStatus(in *InfrastructureStatus, out *metal.InfrastructureStatus, s conversion.Scope) error {
+	out.Networks = *(*[]metal.NetworkStatus)(unsafe.Pointer(&in.Networks))
+	out.LoadBalancerPrefix = (*metal.LoadBalancerPrefixStatus)(unsafe.Pointer(in.LoadBalancerPrefix))
+	out.IPv6LoadBalancerPrefix = (*metal.LoadBalancerPrefixStatus)(unsafe.Pointer(in.IPv6LoadBalancerPrefix))
 	return nil
 }
 
@@ -588,6 +855,9 @@ func Convert_v1alpha1_InfrastructureStatus_To_metal_InfrastructureStatus(in *Inf
 }
 
 func autoConvert_metal_InfrastructureStatus_To_v1alpha1_InfrastructureStatus(in *metal.InfrastructureStatus, out *InfrastructureStatus, s conversion.Scope) error {
+	out.Networks = *(*[]NetworkStatus)(unsafe.Pointer(&in.Networks))
+	out.LoadBalancerPrefix = (*LoadBalancerPrefixStatus)(unsafe.Pointer(in.LoadBalancerPrefix))
+	out.IPv6LoadBalancerPrefix = (*LoadBalancerPrefixStatus)(unsafe.Pointer(in.IPv6LoadBalancerPrefix))
 	return nil
 }
 
@@ -618,6 +888,50 @@ func Convert_metal_LoadBalancerConfig_To_v1alpha1_LoadBalancerConfig(in *metal.L
 	return autoConvert_metal_LoadBalancerConfig_To_v1alpha1_LoadBalancerConfig(in, out, s)
 }
 
+func autoConvert_v1alpha1_LoadBalancerPrefix_To_metal_LoadBalancerPrefix(in *LoadBalancerPrefix, out *metal.LoadBalancerPrefix, s conversion.Scope) error {
+	out.ParentSubnet = in.ParentSubnet
+	out.PrefixLength = in.PrefixLength
+	return nil
+}
+
+// Convert_v1alpha1_LoadBalancerPrefix_To_metal_LoadBalancerPrefix is an autogenerated conversion function.
+func Convert_v1alpha1_LoadBalancerPrefix_To_metal_LoadBalancerPrefix(in *LoadBalancerPrefix, out *metal.LoadBalancerPrefix, s conversion.Scope) error {
+	return autoConvert_v1alpha1_LoadBalancerPrefix_To_metal_LoadBalancerPrefix(in, out, s)
+}
+
+func autoConvert_metal_LoadBalancerPrefix_To_v1alpha1_LoadBalancerPrefix(in *metal.LoadBalancerPrefix, out *LoadBalancerPrefix, s conversion.Scope) error {
+	out.ParentSubnet = in.ParentSubnet
+	out.PrefixLength = in.PrefixLength
+	return nil
+}
+
+// Convert_metal_LoadBalancerPrefix_To_v1alpha1_LoadBalancerPrefix is an autogenerated conversion function.
+func Convert_metal_LoadBalancerPrefix_To_v1alpha1_LoadBalancerPrefix(in *metal.LoadBalancerPrefix, out *LoadBalancerPrefix, s conversion.Scope) error {
+	return autoConvert_metal_LoadBalancerPrefix_To_v1alpha1_LoadBalancerPrefix(in, out, s)
+}
+
+func autoConvert_v1alpha1_LoadBalancerPrefixStatus_To_metal_LoadBalancerPrefixStatus(in *LoadBalancerPrefixStatus, out *metal.LoadBalancerPrefixStatus, s conversion.Scope) error {
+	out.CIDR = in.CIDR
+	out.SubnetRef = (*metal.IPAMObjectReference)(unsafe.Pointer(in.SubnetRef))
+	return nil
+}
+
+// Convert_v1alpha1_LoadBalancerPrefixStatus_To_metal_LoadBalancerPrefixStatus is an autogenerated conversion function.
+func Convert_v1alpha1_LoadBalancerPrefixStatus_To_metal_LoadBalancerPrefixStatus(in *LoadBalancerPrefixStatus, out *metal.LoadBalancerPrefixStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_LoadBalancerPrefixStatus_To_metal_LoadBalancerPrefixStatus(in, out, s)
+}
+
+func autoConvert_metal_LoadBalancerPrefixStatus_To_v1alpha1_LoadBalancerPrefixStatus(in *metal.LoadBalancerPrefixStatus, out *LoadBalancerPrefixStatus, s conversion.Scope) error {
+	out.CIDR = in.CIDR
+	out.SubnetRef = (*IPAMObjectReference)(unsafe.Pointer(in.SubnetRef))
+	return nil
+}
+
+// Convert_metal_LoadBalancerPrefixStatus_To_v1alpha1_LoadBalancerPrefixStatus is an autogenerated conversion function.
+func Convert_metal_LoadBalancerPrefixStatus_To_v1alpha1_LoadBalancerPrefixStatus(in *metal.LoadBalancerPrefixStatus, out *LoadBalancerPrefixStatus, s conversion.Scope) error {
+	return autoConvert_metal_LoadBalancerPrefixStatus_To_v1alpha1_LoadBalancerPrefixStatus(in, out, s)
+}
+
 func autoConvert_v1alpha1_MachineImage_To_metal_MachineImage(in *MachineImage, out *metal.MachineImage, s conversion.Scope) error {
 	out.Name = in.Name
 	out.Version = in.Version
@@ -693,6 +1007,7 @@ func Convert_metal_MachineImages_To_v1alpha1_MachineImages(in *metal.MachineImag
 func autoConvert_v1alpha1_MachineType_To_metal_MachineType(in *MachineType, out *metal.MachineType, s conversion.Scope) error {
 	out.Name = in.Name
 	out.ServerLabels = *(*map[string]string)(unsafe.Pointer(&in.ServerLabels))
+	out.ServerSelector = (*metav1.LabelSelector)(unsafe.Pointer(in.ServerSelector))
 	return nil
 }
 
@@ -704,6 +1019,7 @@ func Convert_v1alpha1_MachineType_To_metal_MachineType(in *MachineType, out *met
 func autoConvert_metal_MachineType_To_v1alpha1_MachineType(in *metal.MachineType, out *MachineType, s conversion.Scope) error {
 	out.Name = in.Name
 	out.ServerLabels = *(*map[string]string)(unsafe.Pointer(&in.ServerLabels))
+	out.ServerSelector = (*metav1.LabelSelector)(unsafe.Pointer(in.ServerSelector))
 	return nil
 }
 
@@ -736,10 +1052,52 @@ func Convert_metal_MetallbConfig_To_v1alpha1_MetallbConfig(in *metal.MetallbConf
 	return autoConvert_metal_MetallbConfig_To_v1alpha1_MetallbConfig(in, out, s)
 }
 
+func autoConvert_v1alpha1_NetworkStatus_To_metal_NetworkStatus(in *NetworkStatus, out *metal.NetworkStatus, s conversion.Scope) error {
+	out.Name = in.Name
+	out.ID = in.ID
+	out.CIDR = in.CIDR
+	out.IPv6CIDR = in.IPv6CIDR
+	out.Gateway = in.Gateway
+	out.IPv6Gateway = in.IPv6Gateway
+	out.DNSResolvers = *(*[]string)(unsafe.Pointer(&in.DNSResolvers))
+	out.NetworkRef = (*metal.IPAMObjectReference)(unsafe.Pointer(in.NetworkRef))
+	out.SubnetRef = (*metal.IPAMObjectReference)(unsafe.Pointer(in.SubnetRef))
+	out.IPv6SubnetRef = (*metal.IPAMObjectReference)(unsafe.Pointer(in.IPv6SubnetRef))
+	return nil
+}
+
+// Convert_v1alpha1_NetworkStatus_To_metal_NetworkStatus is an autogenerated conversion function.
+func Convert_v1alpha1_NetworkStatus_To_metal_NetworkStatus(in *NetworkStatus, out *metal.NetworkStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_NetworkStatus_To_metal_NetworkStatus(in, out, s)
+}
+
+func autoConvert_metal_NetworkStatus_To_v1alpha1_NetworkStatus(in *metal.NetworkStatus, out *NetworkStatus, s conversion.Scope) error {
+	out.Name = in.Name
+	out.ID = in.ID
+	out.CIDR = in.CIDR
+	out.IPv6CIDR = in.IPv6CIDR
+	out.Gateway = in.Gateway
+	out.IPv6Gateway = in.IPv6Gateway
+	out.DNSResolvers = *(*[]string)(unsafe.Pointer(&in.DNSResolvers))
+	out.NetworkRef = (*IPAMObjectReference)(unsafe.Pointer(in.NetworkRef))
+	out.SubnetRef = (*IPAMObjectReference)(unsafe.Pointer(in.SubnetRef))
+	out.IPv6SubnetRef = (*IPAMObjectReference)(unsafe.Pointer(in.IPv6SubnetRef))
+	return nil
+}
+
+// Convert_metal_NetworkStatus_To_v1alpha1_NetworkStatus is an autogenerated conversion function.
+func Convert_metal_NetworkStatus_To_v1alpha1_NetworkStatus(in *metal.NetworkStatus, out *NetworkStatus, s conversion.Scope) error {
+	return autoConvert_metal_NetworkStatus_To_v1alpha1_NetworkStatus(in, out, s)
+}
+
 func autoConvert_v1alpha1_Networks_To_metal_Networks(in *Networks, out *metal.Networks, s conversion.Scope) error {
 	out.Name = in.Name
 	out.CIDR = in.CIDR
+	out.IPv6CIDR = in.IPv6CIDR
 	out.ID = in.ID
+	out.Gateway = in.Gateway
+	out.IPv6Gateway = in.IPv6Gateway
+	out.DNSResolvers = *(*[]string)(unsafe.Pointer(&in.DNSResolvers))
 	return nil
 }
 
@@ -751,7 +1109,11 @@ func Convert_v1alpha1_Networks_To_metal_Networks(in *Networks, out *metal.Networ
 func autoConvert_metal_Networks_To_v1alpha1_Networks(in *metal.Networks, out *Networks, s conversion.Scope) error {
 	out.Name = in.Name
 	out.CIDR = in.CIDR
+	out.IPv6CIDR = in.IPv6CIDR
 	out.ID = in.ID
+	out.Gateway = in.Gateway
+	out.IPv6Gateway = in.IPv6Gateway
+	out.DNSResolvers = *(*[]string)(unsafe.Pointer(&in.DNSResolvers))
 	return nil
 }
 
@@ -760,10 +1122,59 @@ func Convert_metal_Networks_To_v1alpha1_Networks(in *metal.Networks, out *Networ
 	return autoConvert_metal_Networks_To_v1alpha1_Networks(in, out, s)
 }
 
+func autoConvert_v1alpha1_PartitionConfig_To_metal_PartitionConfig(in *PartitionConfig, out *metal.PartitionConfig, s conversion.Scope) error {
+	out.Label = in.Label
+	out.Size = (*resource.Quantity)(unsafe.Pointer(in.Size))
+	return nil
+}
+
+// Convert_v1alpha1_PartitionConfig_To_metal_PartitionConfig is an autogenerated conversion function.
+func Convert_v1alpha1_PartitionConfig_To_metal_PartitionConfig(in *PartitionConfig, out *metal.PartitionConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha1_PartitionConfig_To_metal_PartitionConfig(in, out, s)
+}
+
+func autoConvert_metal_PartitionConfig_To_v1alpha1_PartitionConfig(in *metal.PartitionConfig, out *PartitionConfig, s conversion.Scope) error {
+	out.Label = in.Label
+	out.Size = (*resource.Quantity)(unsafe.Pointer(in.Size))
+	return nil
+}
+
+// Convert_metal_PartitionConfig_To_v1alpha1_PartitionConfig is an autogenerated conversion function.
+func Convert_metal_PartitionConfig_To_v1alpha1_PartitionConfig(in *metal.PartitionConfig, out *PartitionConfig, s conversion.Scope) error {
+	return autoConvert_metal_PartitionConfig_To_v1alpha1_PartitionConfig(in, out, s)
+}
+
+func autoConvert_v1alpha1_RAIDConfig_To_metal_RAIDConfig(in *RAIDConfig, out *metal.RAIDConfig, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Level = metal.RAIDLevel(in.Level)
+	out.Devices = *(*[]string)(unsafe.Pointer(&in.Devices))
+	out.Spares = (*int32)(unsafe.Pointer(in.Spares))
+	return nil
+}
+
+// Convert_v1alpha1_RAIDConfig_To_metal_RAIDConfig is an autogenerated conversion function.
+func Convert_v1alpha1_RAIDConfig_To_metal_RAIDConfig(in *RAIDConfig, out *metal.RAIDConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha1_RAIDConfig_To_metal_RAIDConfig(in, out, s)
+}
+
+func autoConvert_metal_RAIDConfig_To_v1alpha1_RAIDConfig(in *metal.RAIDConfig, out *RAIDConfig, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Level = RAIDLevel(in.Level)
+	out.Devices = *(*[]string)(unsafe.Pointer(&in.Devices))
+	out.Spares = (*int32)(unsafe.Pointer(in.Spares))
+	return nil
+}
+
+// Convert_metal_RAIDConfig_To_v1alpha1_RAIDConfig is an autogenerated conversion function.
+func Convert_metal_RAIDConfig_To_v1alpha1_RAIDConfig(in *metal.RAIDConfig, out *RAIDConfig, s conversion.Scope) error {
+	return autoConvert_metal_RAIDConfig_To_v1alpha1_RAIDConfig(in, out, s)
+}
+
 func autoConvert_v1alpha1_RegionConfig_To_metal_RegionConfig(in *RegionConfig, out *metal.RegionConfig, s conversion.Scope) error {
 	out.Name = in.Name
 	out.Server = in.Server
 	out.CertificateAuthorityData = *(*[]byte)(unsafe.Pointer(&in.CertificateAuthorityData))
+	out.Zones = *(*[]metal.ZoneConfig)(unsafe.Pointer(&in.Zones))
 	return nil
 }
 
@@ -776,6 +1187,7 @@ func autoConvert_metal_RegionConfig_To_v1alpha1_RegionConfig(in *metal.RegionCon
 	out.Name = in.Name
 	out.Server = in.Server
 	out.CertificateAuthorityData = *(*[]byte)(unsafe.Pointer(&in.CertificateAuthorityData))
+	out.Zones = *(*[]ZoneConfig)(unsafe.Pointer(&in.Zones))
 	return nil
 }
 
@@ -784,11 +1196,90 @@ func Convert_metal_RegionConfig_To_v1alpha1_RegionConfig(in *metal.RegionConfig,
 	return autoConvert_metal_RegionConfig_To_v1alpha1_RegionConfig(in, out, s)
 }
 
+func autoConvert_v1alpha1_ServerSettings_To_metal_ServerSettings(in *ServerSettings, out *metal.ServerSettings, s conversion.Scope) error {
+	out.BIOSSettings = *(*map[string]string)(unsafe.Pointer(&in.BIOSSettings))
+	out.BootMode = (*metal.BootMode)(unsafe.Pointer(in.BootMode))
+	out.PowerProfile = (*metal.PowerProfile)(unsafe.Pointer(in.PowerProfile))
+	out.MinimumFirmwareVersion = (*string)(unsafe.Pointer(in.MinimumFirmwareVersion))
+	return nil
+}
+
+// Convert_v1alpha1_ServerSettings_To_metal_ServerSettings is an autogenerated conversion function.
+func Convert_v1alpha1_ServerSettings_To_metal_ServerSettings(in *ServerSettings, out *metal.ServerSettings, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ServerSettings_To_metal_ServerSettings(in, out, s)
+}
+
+func autoConvert_metal_ServerSettings_To_v1alpha1_ServerSettings(in *metal.ServerSettings, out *ServerSettings, s conversion.Scope) error {
+	out.BIOSSettings = *(*map[string]string)(unsafe.Pointer(&in.BIOSSettings))
+	out.BootMode = (*BootMode)(unsafe.Pointer(in.BootMode))
+	out.PowerProfile = (*PowerProfile)(unsafe.Pointer(in.PowerProfile))
+	out.MinimumFirmwareVersion = (*string)(unsafe.Pointer(in.MinimumFirmwareVersion))
+	return nil
+}
+
+// Convert_metal_ServerSettings_To_v1alpha1_ServerSettings is an autogenerated conversion function.
+func Convert_metal_ServerSettings_To_v1alpha1_ServerSettings(in *metal.ServerSettings, out *ServerSettings, s conversion.Scope) error {
+	return autoConvert_metal_ServerSettings_To_v1alpha1_ServerSettings(in, out, s)
+}
+
+func autoConvert_v1alpha1_StorageConfig_To_metal_StorageConfig(in *StorageConfig, out *metal.StorageConfig, s conversion.Scope) error {
+	out.Disks = *(*[]metal.DiskConfig)(unsafe.Pointer(&in.Disks))
+	out.RAIDs = *(*[]metal.RAIDConfig)(unsafe.Pointer(&in.RAIDs))
+	out.Filesystems = *(*[]metal.FilesystemConfig)(unsafe.Pointer(&in.Filesystems))
+	return nil
+}
+
+// Convert_v1alpha1_StorageConfig_To_metal_StorageConfig is an autogenerated conversion function.
+func Convert_v1alpha1_StorageConfig_To_metal_StorageConfig(in *StorageConfig, out *metal.StorageConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha1_StorageConfig_To_metal_StorageConfig(in, out, s)
+}
+
+func autoConvert_metal_StorageConfig_To_v1alpha1_StorageConfig(in *metal.StorageConfig, out *StorageConfig, s conversion.Scope) error {
+	out.Disks = *(*[]DiskConfig)(unsafe.Pointer(&in.Disks))
+	out.RAIDs = *(*[]RAIDConfig)(unsafe.Pointer(&in.RAIDs))
+	out.Filesystems = *(*[]FilesystemConfig)(unsafe.Pointer(&in.Filesystems))
+	return nil
+}
+
+// Convert_metal_StorageConfig_To_v1alpha1_StorageConfig is an autogenerated conversion function.
+func Convert_metal_StorageConfig_To_v1alpha1_StorageConfig(in *metal.StorageConfig, out *StorageConfig, s conversion.Scope) error {
+	return autoConvert_metal_StorageConfig_To_v1alpha1_StorageConfig(in, out, s)
+}
+
+func autoConvert_v1alpha1_WipedServer_To_metal_WipedServer(in *WipedServer, out *metal.WipedServer, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Pool = in.Pool
+	return nil
+}
+
+// Convert_v1alpha1_WipedServer_To_metal_WipedServer is an autogenerated conversion function.
+func Convert_v1alpha1_WipedServer_To_metal_WipedServer(in *WipedServer, out *metal.WipedServer, s conversion.Scope) error {
+	return autoConvert_v1alpha1_WipedServer_To_metal_WipedServer(in, out, s)
+}
+
+func autoConvert_metal_WipedServer_To_v1alpha1_WipedServer(in *metal.WipedServer, out *WipedServer, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Pool = in.Pool
+	return nil
+}
+
+// Convert_metal_WipedServer_To_v1alpha1_WipedServer is an autogenerated conversion function.
+func Convert_metal_WipedServer_To_v1alpha1_WipedServer(in *metal.WipedServer, out *WipedServer, s conversion.Scope) error {
+	return autoConvert_metal_WipedServer_To_v1alpha1_WipedServer(in, out, s)
+}
+
 func autoConvert_v1alpha1_WorkerConfig_To_metal_WorkerConfig(in *WorkerConfig, out *metal.WorkerConfig, s conversion.Scope) error {
 	out.ExtraIgnition = (*metal.IgnitionConfig)(unsafe.Pointer(in.ExtraIgnition))
 	out.ExtraServerLabels = *(*map[string]string)(unsafe.Pointer(&in.ExtraServerLabels))
+	out.ServerSelector = (*metav1.LabelSelector)(unsafe.Pointer(in.ServerSelector))
 	out.IPAMConfig = *(*[]metal.IPAMConfig)(unsafe.Pointer(&in.IPAMConfig))
+	out.ZoneIPAMConfigs = *(*[]metal.ZoneIPAMConfig)(unsafe.Pointer(&in.ZoneIPAMConfigs))
 	out.Metadata = *(*map[string]string)(unsafe.Pointer(&in.Metadata))
+	out.ServerSettings = (*metal.ServerSettings)(unsafe.Pointer(in.ServerSettings))
+	out.HostnamePolicy = (*metal.HostnamePolicy)(unsafe.Pointer(in.HostnamePolicy))
+	out.Storage = (*metal.StorageConfig)(unsafe.Pointer(in.Storage))
+	out.UserDataCompression = (*metal.UserDataCompression)(unsafe.Pointer(in.UserDataCompression))
+	out.ReleasePolicy = (*metal.ReleasePolicy)(unsafe.Pointer(in.ReleasePolicy))
 	return nil
 }
 
@@ -800,8 +1291,15 @@ func Convert_v1alpha1_WorkerConfig_To_metal_WorkerConfig(in *WorkerConfig, out *
 func autoConvert_metal_WorkerConfig_To_v1alpha1_WorkerConfig(in *metal.WorkerConfig, out *WorkerConfig, s conversion.Scope) error {
 	out.ExtraIgnition = (*IgnitionConfig)(unsafe.Pointer(in.ExtraIgnition))
 	out.ExtraServerLabels = *(*map[string]string)(unsafe.Pointer(&in.ExtraServerLabels))
+	out.ServerSelector = (*metav1.LabelSelector)(unsafe.Pointer(in.ServerSelector))
 	out.IPAMConfig = *(*[]IPAMConfig)(unsafe.Pointer(&in.IPAMConfig))
+	out.ZoneIPAMConfigs = *(*[]ZoneIPAMConfig)(unsafe.Pointer(&in.ZoneIPAMConfigs))
 	out.Metadata = *(*map[string]string)(unsafe.Pointer(&in.Metadata))
+	out.ServerSettings = (*ServerSettings)(unsafe.Pointer(in.ServerSettings))
+	out.HostnamePolicy = (*HostnamePolicy)(unsafe.Pointer(in.HostnamePolicy))
+	out.Storage = (*StorageConfig)(unsafe.Pointer(in.Storage))
+	out.UserDataCompression = (*UserDataCompression)(unsafe.Pointer(in.UserDataCompression))
+	out.ReleasePolicy = (*ReleasePolicy)(unsafe.Pointer(in.ReleasePolicy))
 	return nil
 }
 
@@ -810,8 +1308,35 @@ func Convert_metal_WorkerConfig_To_v1alpha1_WorkerConfig(in *metal.WorkerConfig,
 	return autoConvert_metal_WorkerConfig_To_v1alpha1_WorkerConfig(in, out, s)
 }
 
+func autoConvert_v1alpha1_WorkerPoolHash_To_metal_WorkerPoolHash(in *WorkerPoolHash, out *metal.WorkerPoolHash, s conversion.Scope) error {
+	out.Pool = in.Pool
+	out.Version = in.Version
+	out.CurrentHash = in.CurrentHash
+	return nil
+}
+
+// Convert_v1alpha1_WorkerPoolHash_To_metal_WorkerPoolHash is an autogenerated conversion function.
+func Convert_v1alpha1_WorkerPoolHash_To_metal_WorkerPoolHash(in *WorkerPoolHash, out *metal.WorkerPoolHash, s conversion.Scope) error {
+	return autoConvert_v1alpha1_WorkerPoolHash_To_metal_WorkerPoolHash(in, out, s)
+}
+
+func autoConvert_metal_WorkerPoolHash_To_v1alpha1_WorkerPoolHash(in *metal.WorkerPoolHash, out *WorkerPoolHash, s conversion.Scope) error {
+	out.Pool = in.Pool
+	out.Version = in.Version
+	out.CurrentHash = in.CurrentHash
+	return nil
+}
+
+// Convert_metal_WorkerPoolHash_To_v1alpha1_WorkerPoolHash is an autogenerated conversion function.
+func Convert_metal_WorkerPoolHash_To_v1alpha1_WorkerPoolHash(in *metal.WorkerPoolHash, out *WorkerPoolHash, s conversion.Scope) error {
+	return autoConvert_metal_WorkerPoolHash_To_v1alpha1_WorkerPoolHash(in, out, s)
+}
+
 func autoConvert_v1alpha1_WorkerStatus_To_metal_WorkerStatus(in *WorkerStatus, out *metal.WorkerStatus, s conversion.Scope) error {
 	out.MachineImages = *(*[]metal.MachineImage)(unsafe.Pointer(&in.MachineImages))
+	out.WipedServers = *(*[]metal.WipedServer)(unsafe.Pointer(&in.WipedServers))
+	out.WipingPools = *(*[]string)(unsafe.Pointer(&in.WipingPools))
+	out.WorkerPoolHashes = *(*[]metal.WorkerPoolHash)(unsafe.Pointer(&in.WorkerPoolHashes))
 	return nil
 }
 
@@ -822,6 +1347,9 @@ func Convert_v1alpha1_WorkerStatus_To_metal_WorkerStatus(in *WorkerStatus, out *
 
 func autoConvert_metal_WorkerStatus_To_v1alpha1_WorkerStatus(in *metal.WorkerStatus, out *WorkerStatus, s conversion.Scope) error {
 	out.MachineImages = *(*[]MachineImage)(unsafe.Pointer(&in.MachineImages))
+	out.WipedServers = *(*[]WipedServer)(unsafe.Pointer(&in.WipedServers))
+	out.WipingPools = *(*[]string)(unsafe.Pointer(&in.WipingPools))
+	out.WorkerPoolHashes = *(*[]WorkerPoolHash)(unsafe.Pointer(&in.WorkerPoolHashes))
 	return nil
 }
 
@@ -829,3 +1357,47 @@ func autoConvert_metal_WorkerStatus_To_v1alpha1_WorkerStatus(in *metal.WorkerSta
 func Convert_metal_WorkerStatus_To_v1alpha1_WorkerStatus(in *metal.WorkerStatus, out *WorkerStatus, s conversion.Scope) error {
 	return autoConvert_metal_WorkerStatus_To_v1alpha1_WorkerStatus(in, out, s)
 }
+
+func autoConvert_v1alpha1_ZoneConfig_To_metal_ZoneConfig(in *ZoneConfig, out *metal.ZoneConfig, s conversion.Scope) error {
+	out.Name = in.Name
+	out.ServerLabels = *(*map[string]string)(unsafe.Pointer(&in.ServerLabels))
+	return nil
+}
+
+// Convert_v1alpha1_ZoneConfig_To_metal_ZoneConfig is an autogenerated conversion function.
+func Convert_v1alpha1_ZoneConfig_To_metal_ZoneConfig(in *ZoneConfig, out *metal.ZoneConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ZoneConfig_To_metal_ZoneConfig(in, out, s)
+}
+
+func autoConvert_metal_ZoneConfig_To_v1alpha1_ZoneConfig(in *metal.ZoneConfig, out *ZoneConfig, s conversion.Scope) error {
+	out.Name = in.Name
+	out.ServerLabels = *(*map[string]string)(unsafe.Pointer(&in.ServerLabels))
+	return nil
+}
+
+// Convert_metal_ZoneConfig_To_v1alpha1_ZoneConfig is an autogenerated conversion function.
+func Convert_metal_ZoneConfig_To_v1alpha1_ZoneConfig(in *metal.ZoneConfig, out *ZoneConfig, s conversion.Scope) error {
+	return autoConvert_metal_ZoneConfig_To_v1alpha1_ZoneConfig(in, out, s)
+}
+
+func autoConvert_v1alpha1_ZoneIPAMConfig_To_metal_ZoneIPAMConfig(in *ZoneIPAMConfig, out *metal.ZoneIPAMConfig, s conversion.Scope) error {
+	out.Zone = in.Zone
+	out.IPAMConfig = *(*[]metal.IPAMConfig)(unsafe.Pointer(&in.IPAMConfig))
+	return nil
+}
+
+// Convert_v1alpha1_ZoneIPAMConfig_To_metal_ZoneIPAMConfig is an autogenerated conversion function.
+func Convert_v1alpha1_ZoneIPAMConfig_To_metal_ZoneIPAMConfig(in *ZoneIPAMConfig, out *metal.ZoneIPAMConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ZoneIPAMConfig_To_metal_ZoneIPAMConfig(in, out, s)
+}
+
+func autoConvert_metal_ZoneIPAMConfig_To_v1alpha1_ZoneIPAMConfig(in *metal.ZoneIPAMConfig, out *ZoneIPAMConfig, s conversion.Scope) error {
+	out.Zone = in.Zone
+	out.IPAMConfig = *(*[]IPAMConfig)(unsafe.Pointer(&in.IPAMConfig))
+	return nil
+}
+
+// Convert_metal_ZoneIPAMConfig_To_v1alpha1_ZoneIPAMConfig is an autogenerated conversion function.
+func Convert_metal_ZoneIPAMConfig_To_v1alpha1_ZoneIPAMConfig(in *metal.ZoneIPAMConfig, out *ZoneIPAMConfig, s conversion.Scope) error {
+	return autoConvert_metal_ZoneIPAMConfig_To_v1alpha1_ZoneIPAMConfig(in, out, s)
+}
@@ -9,6 +9,8 @@ import (
 	gardenercore "github.com/gardener/gardener/pkg/apis/core"
 	gardenercorehelper "github.com/gardener/gardener/pkg/apis/core/helper"
 	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/strings/slices"
 
@@ -34,6 +36,42 @@ func ValidateCloudProfileConfig(cpConfig *apismetal.CloudProfileConfig, machineI
 		}
 	}
 
+	regionConfigsPath := fldPath.Child("regionConfigs")
+	for i, regionConfig := range cpConfig.RegionConfigs {
+		allErrs = append(allErrs, validateZones(regionConfig.Zones, regionConfigsPath.Index(i).Child("zones"))...)
+	}
+
+	if cpConfig.HostnamePolicy != nil {
+		allErrs = append(allErrs, ValidateHostnamePolicy(cpConfig.HostnamePolicy, fldPath.Child("hostnamePolicy"))...)
+	}
+
+	machineTypesPath := fldPath.Child("machineTypes")
+	for i, machineType := range cpConfig.MachineTypes {
+		if machineType.ServerSelector != nil {
+			allErrs = append(allErrs, metav1validation.ValidateLabelSelector(machineType.ServerSelector, metav1validation.LabelSelectorValidationOptions{}, machineTypesPath.Index(i).Child("serverSelector"))...)
+		}
+	}
+
+	return allErrs
+}
+
+func validateZones(zones []apismetal.ZoneConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	zoneNames := sets.New[string]()
+
+	for i, zone := range zones {
+		idxPath := fldPath.Index(i)
+
+		if zone.Name == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("name"), "zone name is required"))
+		} else if zoneNames.Has(zone.Name) {
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("name"), zone.Name))
+		}
+		zoneNames.Insert(zone.Name)
+
+		allErrs = append(allErrs, metav1validation.ValidateLabels(zone.ServerLabels, idxPath.Child("serverLabels"))...)
+	}
+
 	return allErrs
 }
 
@@ -9,6 +9,7 @@ import (
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gstruct"
 	"github.com/onsi/gomega/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/ptr"
 
@@ -123,5 +124,69 @@ var _ = Describe("CloudProfileConfig validation", func() {
 			})
 		})
 
+		Describe("region config validation", func() {
+			It("should allow zones with server labels", func() {
+				cloudProfileConfig.RegionConfigs = []apismetal.RegionConfig{{
+					Name: "region",
+					Zones: []apismetal.ZoneConfig{
+						{Name: "zone-a", ServerLabels: map[string]string{"topology.metal.ironcore.dev/rack": "rack-a"}},
+						{Name: "zone-b"},
+					},
+				}}
+
+				Expect(ValidateCloudProfileConfig(cloudProfileConfig, machineImages, nilPath)).To(BeEmpty())
+			})
+
+			It("should forbid missing and duplicate zone names and invalid server labels", func() {
+				cloudProfileConfig.RegionConfigs = []apismetal.RegionConfig{{
+					Name: "region",
+					Zones: []apismetal.ZoneConfig{
+						{Name: "zone-a"},
+						{Name: "zone-a"},
+						{ServerLabels: map[string]string{"rack": "invalid value"}},
+					},
+				}}
+
+				Expect(ValidateCloudProfileConfig(cloudProfileConfig, machineImages, nilPath)).To(ConsistOf(
+					SimpleMatchField(field.ErrorTypeDuplicate, "regionConfigs[0].zones[1].name"),
+					SimpleMatchField(field.ErrorTypeRequired, "regionConfigs[0].zones[2].name"),
+					InvalidField("regionConfigs[0].zones[2].serverLabels"),
+				))
+			})
+		})
+
+		Describe("machine type validation", func() {
+			It("should allow machine types with server selectors", func() {
+				cloudProfileConfig.MachineTypes = []apismetal.MachineType{{
+					Name: "large",
+					ServerSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"size": "large"},
+						MatchExpressions: []metav1.LabelSelectorRequirement{
+							{Key: "cpu", Operator: metav1.LabelSelectorOpIn, Values: []string{"gen1", "gen2"}},
+							{Key: "maintenance", Operator: metav1.LabelSelectorOpDoesNotExist},
+						},
+					},
+				}}
+
+				Expect(ValidateCloudProfileConfig(cloudProfileConfig, machineImages, nilPath)).To(BeEmpty())
+			})
+
+			It("should forbid invalid server selectors", func() {
+				cloudProfileConfig.MachineTypes = []apismetal.MachineType{{
+					Name: "large",
+					ServerSelector: &metav1.LabelSelector{
+						MatchExpressions: []metav1.LabelSelectorRequirement{
+							{Key: "cpu", Operator: metav1.LabelSelectorOpIn},
+							{Key: "maintenance", Operator: metav1.LabelSelectorOpDoesNotExist, Values: []string{"true"}},
+						},
+					},
+				}}
+
+				Expect(ValidateCloudProfileConfig(cloudProfileConfig, machineImages, nilPath)).To(ConsistOf(
+					SimpleMatchField(field.ErrorTypeRequired, "machineTypes[0].serverSelector.matchExpressions[0].values"),
+					SimpleMatchField(field.ErrorTypeForbidden, "machineTypes[0].serverSelector.matchExpressions[1].values"),
+				))
+			})
+		})
 	})
 })
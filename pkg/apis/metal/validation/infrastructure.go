@@ -4,17 +4,267 @@
 package validation
 
 import (
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+
+	"github.com/gardener/gardener/pkg/apis/core"
+	cidrvalidation "github.com/gardener/gardener/pkg/utils/validation/cidr"
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	apismetal "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal"
 )
 
+// loadBalancerPrefixName is the name the infrastructure actuator gives the IPAM Subnet of the LoadBalancer prefix,
+// hence no network may use it.
+const loadBalancerPrefixName = "loadbalancer"
+
 // ValidateInfrastructureConfig validates a InfrastructureConfig object.
-func ValidateInfrastructureConfig(infra *apismetal.InfrastructureConfig, nodesCIDR, podsCIDR, servicesCIDR *string, fldPath *field.Path) field.ErrorList {
-	return field.ErrorList{}
+func ValidateInfrastructureConfig(infra *apismetal.InfrastructureConfig, ipFamilies []core.IPFamily, nodesCIDR, podsCIDR, servicesCIDR *string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	var (
+		networkingPath = field.NewPath("spec", "networking")
+		nodes          cidrvalidation.CIDR
+		disjoint       []cidrvalidation.CIDR
+	)
+
+	if nodesCIDR != nil {
+		nodes = cidrvalidation.NewCIDR(*nodesCIDR, networkingPath.Child("nodes"))
+	}
+	if podsCIDR != nil {
+		disjoint = append(disjoint, cidrvalidation.NewCIDR(*podsCIDR, networkingPath.Child("pods")))
+	}
+	if servicesCIDR != nil {
+		disjoint = append(disjoint, cidrvalidation.NewCIDR(*servicesCIDR, networkingPath.Child("services")))
+	}
+
+	// Shoots without explicit IP families are IPv4 single-stack.
+	if len(ipFamilies) == 0 {
+		ipFamilies = []core.IPFamily{core.IPFamilyIPv4}
+	}
+	dualStack := slices.Contains(ipFamilies, core.IPFamilyIPv4) && slices.Contains(ipFamilies, core.IPFamilyIPv6)
+
+	// The IPv6 pod and service networks of dual-stack shoots complement the IPv4 ones of the shoot spec.
+	var ipv6ShootCIDRs []cidrvalidation.CIDR
+	for _, shootNetwork := range []struct {
+		cidr string
+		path *field.Path
+	}{
+		{infra.IPv6PodsCIDR, fldPath.Child("ipv6PodsCIDR")},
+		{infra.IPv6ServicesCIDR, fldPath.Child("ipv6ServicesCIDR")},
+	} {
+		if shootNetwork.cidr == "" {
+			continue
+		}
+		if !dualStack {
+			allErrs = append(allErrs, field.Forbidden(shootNetwork.path, "an IPv6 CIDR is only allowed for dual-stack shoots"))
+			continue
+		}
+
+		cidr := cidrvalidation.NewCIDR(shootNetwork.cidr, shootNetwork.path)
+		if errs := cidr.ValidateParse(); len(errs) > 0 {
+			allErrs = append(allErrs, errs...)
+			continue
+		}
+		if errs := cidr.ValidateIPFamily(string(core.IPFamilyIPv6)); len(errs) > 0 {
+			allErrs = append(allErrs, errs...)
+			continue
+		}
+		allErrs = append(allErrs, cidrvalidation.ValidateCIDRIsCanonical(shootNetwork.path, shootNetwork.cidr)...)
+		ipv6ShootCIDRs = append(ipv6ShootCIDRs, cidr)
+	}
+	allErrs = append(allErrs, cidrvalidation.ValidateCIDROverlap(ipv6ShootCIDRs, false)...)
+	disjoint = append(disjoint, ipv6ShootCIDRs...)
+
+	var (
+		networksPath = fldPath.Child("networks")
+		networkNames = sets.New[string]()
+		networkCIDRs []cidrvalidation.CIDR
+	)
+
+	for i, network := range infra.Networks {
+		idxPath := networksPath.Index(i)
+
+		if network.Name == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("name"), "network name is required"))
+		} else {
+			for _, msg := range validation.IsDNS1123Label(network.Name) {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("name"), network.Name, msg))
+			}
+			// The IPAM Subnet of the IPv6 CIDR of a network is named after the network with this suffix.
+			if strings.HasSuffix(network.Name, "-ipv6") {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("name"), network.Name, "must not end with -ipv6, which is reserved for the IPv6 subnets of networks"))
+			}
+			// The IPAM Subnet of the LoadBalancer prefix shares the naming scheme of the network subnets.
+			if network.Name == loadBalancerPrefixName {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("name"), network.Name, "is reserved for the subnet of the LoadBalancer prefix"))
+			}
+			if networkNames.Has(network.Name) {
+				allErrs = append(allErrs, field.Duplicate(idxPath.Child("name"), network.Name))
+			}
+			networkNames.Insert(network.Name)
+		}
+
+		for j, resolver := range network.DNSResolvers {
+			if net.ParseIP(resolver) == nil {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("dnsResolvers").Index(j), resolver, "must be a valid IP address"))
+			}
+		}
+
+		if network.CIDR == "" && network.IPv6CIDR == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("cidr"), "network CIDR is required"))
+			continue
+		}
+
+		for _, family := range []struct {
+			ipFamily    core.IPFamily
+			cidr        string
+			gateway     string
+			cidrPath    *field.Path
+			gatewayPath *field.Path
+		}{
+			{core.IPFamilyIPv4, network.CIDR, network.Gateway, idxPath.Child("cidr"), idxPath.Child("gateway")},
+			{core.IPFamilyIPv6, network.IPv6CIDR, network.IPv6Gateway, idxPath.Child("ipv6CIDR"), idxPath.Child("ipv6Gateway")},
+		} {
+			enabled := slices.Contains(ipFamilies, family.ipFamily)
+			if family.cidr == "" {
+				if enabled {
+					allErrs = append(allErrs, field.Required(family.cidrPath, fmt.Sprintf("an %s CIDR is required as the shoot uses the %s IP family", family.ipFamily, family.ipFamily)))
+				}
+				continue
+			}
+			if !enabled {
+				allErrs = append(allErrs, field.Forbidden(family.cidrPath, fmt.Sprintf("an %s CIDR is not allowed as the shoot does not use the %s IP family", family.ipFamily, family.ipFamily)))
+				continue
+			}
+
+			cidr, errs := validateNetworkCIDR(family.cidr, family.gateway, family.ipFamily, nodes, disjoint, family.cidrPath, family.gatewayPath)
+			allErrs = append(allErrs, errs...)
+			if cidr != nil {
+				networkCIDRs = append(networkCIDRs, cidr)
+			}
+		}
+	}
+
+	allErrs = append(allErrs, cidrvalidation.ValidateCIDROverlap(networkCIDRs, false)...)
+
+	if infra.LoadBalancerPrefix != nil {
+		// The LoadBalancer prefix is taken from the IPv4 addresses unless the shoot is IPv6-only.
+		ipFamily := core.IPFamilyIPv4
+		if !slices.Contains(ipFamilies, core.IPFamilyIPv4) {
+			ipFamily = core.IPFamilyIPv6
+		}
+		allErrs = append(allErrs, validateLoadBalancerPrefix(infra.LoadBalancerPrefix, ipFamily, fldPath.Child("loadBalancerPrefix"))...)
+	}
+	if infra.IPv6LoadBalancerPrefix != nil {
+		if !dualStack {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("ipv6LoadBalancerPrefix"), "an IPv6 LoadBalancer prefix is only allowed for dual-stack shoots"))
+		} else {
+			allErrs = append(allErrs, validateLoadBalancerPrefix(infra.IPv6LoadBalancerPrefix, core.IPFamilyIPv6, fldPath.Child("ipv6LoadBalancerPrefix"))...)
+		}
+	}
+
+	return allErrs
+}
+
+func validateLoadBalancerPrefix(prefix *apismetal.LoadBalancerPrefix, ipFamily core.IPFamily, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if prefix.ParentSubnet == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("parentSubnet"), "parent subnet is required"))
+	} else {
+		for _, msg := range validation.IsDNS1123Subdomain(prefix.ParentSubnet) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("parentSubnet"), prefix.ParentSubnet, msg))
+		}
+	}
+
+	maxPrefixLength := int32(32)
+	if ipFamily == core.IPFamilyIPv6 {
+		maxPrefixLength = 128
+	}
+	if prefix.PrefixLength < 1 || prefix.PrefixLength > maxPrefixLength {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("prefixLength"), prefix.PrefixLength, fmt.Sprintf("must be between 1 and %d for an %s prefix", maxPrefixLength, ipFamily)))
+	}
+
+	return allErrs
+}
+
+// validateNetworkCIDR validates a CIDR of the given IP family and its gateway. The CIDR must be a subset of the
+// nodes CIDR and must not overlap with the disjoint CIDRs, as far as they belong to the same IP family.
+func validateNetworkCIDR(cidrString, gateway string, ipFamily core.IPFamily, nodes cidrvalidation.CIDR, disjoint []cidrvalidation.CIDR, cidrPath, gatewayPath *field.Path) (cidrvalidation.CIDR, field.ErrorList) {
+	allErrs := field.ErrorList{}
+
+	cidr := cidrvalidation.NewCIDR(cidrString, cidrPath)
+	if errs := cidr.ValidateParse(); len(errs) > 0 {
+		return nil, errs
+	}
+	if errs := cidr.ValidateIPFamily(string(ipFamily)); len(errs) > 0 {
+		return nil, errs
+	}
+	allErrs = append(allErrs, cidrvalidation.ValidateCIDRIsCanonical(cidrPath, cidrString)...)
+
+	if nodes != nil && isIPFamily(nodes, ipFamily) {
+		allErrs = append(allErrs, nodes.ValidateSubset(cidr)...)
+	}
+	for _, other := range disjoint {
+		if isIPFamily(other, ipFamily) {
+			allErrs = append(allErrs, other.ValidateNotOverlap(cidr)...)
+		}
+	}
+
+	if gateway != "" {
+		if ip := net.ParseIP(gateway); ip == nil {
+			allErrs = append(allErrs, field.Invalid(gatewayPath, gateway, "must be a valid IP address"))
+		} else if !cidr.GetIPNet().Contains(ip) {
+			allErrs = append(allErrs, field.Invalid(gatewayPath, gateway, fmt.Sprintf("must be within the network CIDR %s", cidrString)))
+		}
+	}
+
+	return cidr, allErrs
+}
+
+func isIPFamily(cidr cidrvalidation.CIDR, ipFamily core.IPFamily) bool {
+	return cidr.Parse() && len(cidr.ValidateIPFamily(string(ipFamily))) == 0
 }
 
 // ValidateInfrastructureConfigUpdate validates a InfrastructureConfig object.
 func ValidateInfrastructureConfigUpdate(oldConfig, newConfig *apismetal.InfrastructureConfig, fldPath *field.Path) field.ErrorList {
-	return field.ErrorList{}
+	allErrs := field.ErrorList{}
+	networksPath := fldPath.Child("networks")
+
+	// Networks which have already been provisioned might be used by nodes, hence they must not be changed or removed.
+	for _, oldNetwork := range oldConfig.Networks {
+		idx := slices.IndexFunc(newConfig.Networks, func(network apismetal.Networks) bool {
+			return network.Name == oldNetwork.Name
+		})
+		if idx < 0 {
+			allErrs = append(allErrs, field.Forbidden(networksPath, fmt.Sprintf("network %q must not be removed", oldNetwork.Name)))
+			continue
+		}
+
+		newNetwork := newConfig.Networks[idx]
+		idxPath := networksPath.Index(idx)
+		allErrs = append(allErrs, apivalidation.ValidateImmutableField(newNetwork.CIDR, oldNetwork.CIDR, idxPath.Child("cidr"))...)
+		allErrs = append(allErrs, apivalidation.ValidateImmutableField(newNetwork.IPv6CIDR, oldNetwork.IPv6CIDR, idxPath.Child("ipv6CIDR"))...)
+		allErrs = append(allErrs, apivalidation.ValidateImmutableField(newNetwork.ID, oldNetwork.ID, idxPath.Child("id"))...)
+	}
+
+	// A reserved LoadBalancer prefix is in use by the load balancers of the shoot, hence it must not be changed.
+	if oldConfig.LoadBalancerPrefix != nil {
+		allErrs = append(allErrs, apivalidation.ValidateImmutableField(newConfig.LoadBalancerPrefix, oldConfig.LoadBalancerPrefix, fldPath.Child("loadBalancerPrefix"))...)
+	}
+	if oldConfig.IPv6LoadBalancerPrefix != nil {
+		allErrs = append(allErrs, apivalidation.ValidateImmutableField(newConfig.IPv6LoadBalancerPrefix, oldConfig.IPv6LoadBalancerPrefix, fldPath.Child("ipv6LoadBalancerPrefix"))...)
+	}
+
+	// Like the pod and service networks of the shoot spec, their IPv6 counterparts cannot be changed.
+	allErrs = append(allErrs, apivalidation.ValidateImmutableField(newConfig.IPv6PodsCIDR, oldConfig.IPv6PodsCIDR, fldPath.Child("ipv6PodsCIDR"))...)
+	allErrs = append(allErrs, apivalidation.ValidateImmutableField(newConfig.IPv6ServicesCIDR, oldConfig.IPv6ServicesCIDR, fldPath.Child("ipv6ServicesCIDR"))...)
+
+	return allErrs
 }
@@ -0,0 +1,309 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"github.com/gardener/gardener/pkg/apis/core"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+
+	apismetal "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal"
+)
+
+var _ = Describe("InfrastructureConfig validation", func() {
+	var (
+		infrastructureConfig *apismetal.InfrastructureConfig
+		fldPath              *field.Path
+
+		nodes    = ptr.To("10.0.0.0/16")
+		pods     = ptr.To("100.96.0.0/11")
+		services = ptr.To("100.64.0.0/13")
+	)
+
+	BeforeEach(func() {
+		fldPath = field.NewPath("infrastructureConfig")
+		infrastructureConfig = &apismetal.InfrastructureConfig{
+			Networks: []apismetal.Networks{
+				{Name: "worker-network-1", CIDR: "10.0.1.0/24", ID: "1"},
+				{Name: "worker-network-2", CIDR: "10.0.2.0/24", ID: "2"},
+			},
+		}
+	})
+
+	Describe("#ValidateInfrastructureConfig", func() {
+		It("should return no errors for a valid configuration", func() {
+			Expect(ValidateInfrastructureConfig(infrastructureConfig, nil, nodes, pods, services, fldPath)).To(BeEmpty())
+		})
+
+		It("should return no errors for a configuration without networks", func() {
+			Expect(ValidateInfrastructureConfig(&apismetal.InfrastructureConfig{}, nil, nodes, pods, services, fldPath)).To(BeEmpty())
+		})
+
+		It("should forbid missing, invalid and duplicate network names", func() {
+			infrastructureConfig.Networks = append(infrastructureConfig.Networks,
+				apismetal.Networks{CIDR: "10.0.3.0/24"},
+				apismetal.Networks{Name: "Invalid_Name", CIDR: "10.0.4.0/24"},
+				apismetal.Networks{Name: "worker-network-1", CIDR: "10.0.5.0/24"},
+			)
+
+			Expect(ValidateInfrastructureConfig(infrastructureConfig, nil, nodes, pods, services, fldPath)).To(ConsistOf(
+				SimpleMatchField(field.ErrorTypeRequired, "infrastructureConfig.networks[2].name"),
+				InvalidField("infrastructureConfig.networks[3].name"),
+				SimpleMatchField(field.ErrorTypeDuplicate, "infrastructureConfig.networks[4].name"),
+			))
+		})
+
+		It("should forbid missing, invalid and non canonical CIDRs", func() {
+			infrastructureConfig.Networks[0].CIDR = ""
+			infrastructureConfig.Networks[1].CIDR = "foo"
+			infrastructureConfig.Networks = append(infrastructureConfig.Networks,
+				apismetal.Networks{Name: "worker-network-3", CIDR: "10.0.3.1/24"},
+			)
+
+			Expect(ValidateInfrastructureConfig(infrastructureConfig, nil, nodes, pods, services, fldPath)).To(ConsistOf(
+				SimpleMatchField(field.ErrorTypeRequired, "infrastructureConfig.networks[0].cidr"),
+				InvalidField("infrastructureConfig.networks[1].cidr"),
+				InvalidField("infrastructureConfig.networks[2].cidr"),
+			))
+		})
+
+		It("should forbid networks outside of the nodes CIDR", func() {
+			infrastructureConfig.Networks[1].CIDR = "10.1.0.0/24"
+
+			Expect(ValidateInfrastructureConfig(infrastructureConfig, nil, nodes, pods, services, fldPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal(field.ErrorTypeInvalid),
+					"Field":  Equal("infrastructureConfig.networks[1].cidr"),
+					"Detail": ContainSubstring("spec.networking.nodes"),
+				})),
+			))
+		})
+
+		It("should forbid networks overlapping with the pod and service CIDRs", func() {
+			infrastructureConfig.Networks[0].CIDR = "100.96.0.0/24"
+			infrastructureConfig.Networks[1].CIDR = "100.64.0.0/24"
+
+			Expect(ValidateInfrastructureConfig(infrastructureConfig, nil, nil, pods, services, fldPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal(field.ErrorTypeInvalid),
+					"Field":  Equal("infrastructureConfig.networks[0].cidr"),
+					"Detail": ContainSubstring("spec.networking.pods"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal(field.ErrorTypeInvalid),
+					"Field":  Equal("infrastructureConfig.networks[1].cidr"),
+					"Detail": ContainSubstring("spec.networking.services"),
+				})),
+			))
+		})
+
+		It("should forbid overlapping networks", func() {
+			infrastructureConfig.Networks[1].CIDR = "10.0.0.0/22"
+
+			Expect(ValidateInfrastructureConfig(infrastructureConfig, nil, nodes, pods, services, fldPath)).To(ConsistOf(
+				InvalidField("infrastructureConfig.networks[1].cidr"),
+			))
+		})
+
+		It("should allow dual-stack networks for dual-stack shoots", func() {
+			infrastructureConfig.Networks[0].IPv6CIDR = "2001:db8:1::/64"
+			infrastructureConfig.Networks[0].IPv6Gateway = "2001:db8:1::fe"
+			infrastructureConfig.Networks[1].IPv6CIDR = "2001:db8:2::/64"
+
+			Expect(ValidateInfrastructureConfig(infrastructureConfig, []core.IPFamily{core.IPFamilyIPv4, core.IPFamilyIPv6}, nodes, pods, services, fldPath)).To(BeEmpty())
+		})
+
+		It("should allow IPv6 pod and service networks and LoadBalancer prefixes for dual-stack shoots", func() {
+			infrastructureConfig.Networks[0].IPv6CIDR = "2001:db8:1::/64"
+			infrastructureConfig.Networks[1].IPv6CIDR = "2001:db8:2::/64"
+			infrastructureConfig.IPv6PodsCIDR = "fd00:10:96::/56"
+			infrastructureConfig.IPv6ServicesCIDR = "fd00:10:64::/112"
+			infrastructureConfig.IPv6LoadBalancerPrefix = &apismetal.LoadBalancerPrefix{ParentSubnet: "loadbalancer-pool-ipv6", PrefixLength: 120}
+
+			Expect(ValidateInfrastructureConfig(infrastructureConfig, []core.IPFamily{core.IPFamilyIPv4, core.IPFamilyIPv6}, nodes, pods, services, fldPath)).To(BeEmpty())
+		})
+
+		It("should forbid invalid and overlapping IPv6 pod and service networks", func() {
+			infrastructureConfig.Networks[0].IPv6CIDR = "2001:db8:1::/64"
+			infrastructureConfig.Networks[1].IPv6CIDR = "2001:db8:2::/64"
+			infrastructureConfig.IPv6PodsCIDR = "2001:db8::/32"
+			infrastructureConfig.IPv6ServicesCIDR = "10.0.0.0/16"
+
+			Expect(ValidateInfrastructureConfig(infrastructureConfig, []core.IPFamily{core.IPFamilyIPv4, core.IPFamilyIPv6}, nodes, pods, services, fldPath)).To(ConsistOf(
+				InvalidField("infrastructureConfig.ipv6ServicesCIDR"),
+				InvalidField("infrastructureConfig.networks[0].ipv6CIDR"),
+				InvalidField("infrastructureConfig.networks[1].ipv6CIDR"),
+			))
+		})
+
+		It("should forbid IPv6 pod and service networks and LoadBalancer prefixes for single-stack shoots", func() {
+			infrastructureConfig.IPv6PodsCIDR = "fd00:10:96::/56"
+			infrastructureConfig.IPv6ServicesCIDR = "fd00:10:64::/112"
+			infrastructureConfig.IPv6LoadBalancerPrefix = &apismetal.LoadBalancerPrefix{ParentSubnet: "loadbalancer-pool-ipv6", PrefixLength: 120}
+
+			Expect(ValidateInfrastructureConfig(infrastructureConfig, nil, nodes, pods, services, fldPath)).To(ConsistOf(
+				SimpleMatchField(field.ErrorTypeForbidden, "infrastructureConfig.ipv6PodsCIDR"),
+				SimpleMatchField(field.ErrorTypeForbidden, "infrastructureConfig.ipv6ServicesCIDR"),
+				SimpleMatchField(field.ErrorTypeForbidden, "infrastructureConfig.ipv6LoadBalancerPrefix"),
+			))
+		})
+
+		It("should forbid network names which collide with the IPv6 subnets of other networks", func() {
+			infrastructureConfig.Networks[1].Name = "worker-network-1-ipv6"
+
+			Expect(ValidateInfrastructureConfig(infrastructureConfig, nil, nodes, pods, services, fldPath)).To(ConsistOf(
+				InvalidField("infrastructureConfig.networks[1].name"),
+			))
+		})
+
+		It("should forbid a network name which collides with the subnet of the LoadBalancer prefix", func() {
+			infrastructureConfig.Networks[1].Name = "loadbalancer"
+
+			Expect(ValidateInfrastructureConfig(infrastructureConfig, nil, nodes, pods, services, fldPath)).To(ConsistOf(
+				InvalidField("infrastructureConfig.networks[1].name"),
+			))
+		})
+
+		It("should require a CIDR for every IP family of the shoot", func() {
+			infrastructureConfig.Networks[1].IPv6CIDR = "2001:db8:2::/64"
+
+			Expect(ValidateInfrastructureConfig(infrastructureConfig, []core.IPFamily{core.IPFamilyIPv4, core.IPFamilyIPv6}, nodes, pods, services, fldPath)).To(ConsistOf(
+				SimpleMatchField(field.ErrorTypeRequired, "infrastructureConfig.networks[0].ipv6CIDR"),
+			))
+		})
+
+		It("should validate IPv6-only networks against the shoot networks", func() {
+			infrastructureConfig.Networks = []apismetal.Networks{
+				{Name: "worker-network-1", IPv6CIDR: "2001:db8:0:1::/64"},
+				{Name: "worker-network-2", IPv6CIDR: "2001:db9::/64"},
+				{Name: "worker-network-3", CIDR: "10.0.3.0/24", IPv6CIDR: "10.0.4.0/24"},
+			}
+
+			Expect(ValidateInfrastructureConfig(infrastructureConfig, []core.IPFamily{core.IPFamilyIPv6}, ptr.To("2001:db8::/48"), nil, nil, fldPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal(field.ErrorTypeInvalid),
+					"Field":  Equal("infrastructureConfig.networks[1].ipv6CIDR"),
+					"Detail": ContainSubstring("spec.networking.nodes"),
+				})),
+				SimpleMatchField(field.ErrorTypeForbidden, "infrastructureConfig.networks[2].cidr"),
+				InvalidField("infrastructureConfig.networks[2].ipv6CIDR"),
+			))
+		})
+
+		It("should allow a gateway within the network CIDR and valid DNS resolvers", func() {
+			infrastructureConfig.Networks[0].Gateway = "10.0.1.254"
+			infrastructureConfig.Networks[0].DNSResolvers = []string{"10.0.0.53", "2001:db8::53"}
+
+			Expect(ValidateInfrastructureConfig(infrastructureConfig, nil, nodes, pods, services, fldPath)).To(BeEmpty())
+		})
+
+		It("should forbid invalid gateways and DNS resolvers", func() {
+			infrastructureConfig.Networks[0].Gateway = "foo"
+			infrastructureConfig.Networks[0].DNSResolvers = []string{"10.0.0.53", "bar"}
+			infrastructureConfig.Networks[1].Gateway = "10.0.1.1"
+
+			Expect(ValidateInfrastructureConfig(infrastructureConfig, nil, nodes, pods, services, fldPath)).To(ConsistOf(
+				InvalidField("infrastructureConfig.networks[0].gateway"),
+				InvalidField("infrastructureConfig.networks[0].dnsResolvers[1]"),
+				InvalidField("infrastructureConfig.networks[1].gateway"),
+			))
+		})
+	})
+
+	Describe("#ValidateInfrastructureConfig LoadBalancerPrefix", func() {
+		It("should allow a LoadBalancer prefix with a parent subnet", func() {
+			infrastructureConfig.LoadBalancerPrefix = &apismetal.LoadBalancerPrefix{ParentSubnet: "loadbalancer-pool", PrefixLength: 28}
+
+			Expect(ValidateInfrastructureConfig(infrastructureConfig, nil, nodes, pods, services, fldPath)).To(BeEmpty())
+		})
+
+		It("should forbid a LoadBalancer prefix without parent subnet and with an invalid length", func() {
+			infrastructureConfig.LoadBalancerPrefix = &apismetal.LoadBalancerPrefix{PrefixLength: 129}
+
+			Expect(ValidateInfrastructureConfig(infrastructureConfig, nil, nodes, pods, services, fldPath)).To(ConsistOf(
+				SimpleMatchField(field.ErrorTypeRequired, "infrastructureConfig.loadBalancerPrefix.parentSubnet"),
+				InvalidField("infrastructureConfig.loadBalancerPrefix.prefixLength"),
+			))
+		})
+
+		It("should validate the prefix length against the IP family of the LoadBalancer prefix", func() {
+			infrastructureConfig.LoadBalancerPrefix = &apismetal.LoadBalancerPrefix{ParentSubnet: "loadbalancer-pool", PrefixLength: 64}
+			infrastructureConfig.IPv6LoadBalancerPrefix = &apismetal.LoadBalancerPrefix{ParentSubnet: "loadbalancer-pool-ipv6", PrefixLength: 120}
+			infrastructureConfig.Networks[0].IPv6CIDR = "2001:db8:1::/64"
+			infrastructureConfig.Networks[1].IPv6CIDR = "2001:db8:2::/64"
+
+			Expect(ValidateInfrastructureConfig(infrastructureConfig, []core.IPFamily{core.IPFamilyIPv4, core.IPFamilyIPv6}, nodes, pods, services, fldPath)).To(ConsistOf(
+				InvalidField("infrastructureConfig.loadBalancerPrefix.prefixLength"),
+			))
+		})
+
+		It("should allow an IPv6 prefix length for IPv6-only shoots", func() {
+			infrastructureConfig.Networks = []apismetal.Networks{{Name: "worker-network-1", IPv6CIDR: "2001:db8:0:1::/64"}}
+			infrastructureConfig.LoadBalancerPrefix = &apismetal.LoadBalancerPrefix{ParentSubnet: "loadbalancer-pool", PrefixLength: 120}
+
+			Expect(ValidateInfrastructureConfig(infrastructureConfig, []core.IPFamily{core.IPFamilyIPv6}, ptr.To("2001:db8::/48"), nil, nil, fldPath)).To(BeEmpty())
+		})
+	})
+
+	Describe("#ValidateInfrastructureConfigUpdate", func() {
+		It("should return no errors for an unchanged config", func() {
+			Expect(ValidateInfrastructureConfigUpdate(infrastructureConfig, infrastructureConfig, fldPath)).To(BeEmpty())
+		})
+
+		It("should allow adding networks", func() {
+			newConfig := infrastructureConfig.DeepCopy()
+			newConfig.Networks = append(newConfig.Networks, apismetal.Networks{Name: "worker-network-3", CIDR: "10.0.3.0/24"})
+
+			Expect(ValidateInfrastructureConfigUpdate(infrastructureConfig, newConfig, fldPath)).To(BeEmpty())
+		})
+
+		It("should forbid changing the CIDR or ID of an existing network", func() {
+			newConfig := infrastructureConfig.DeepCopy()
+			newConfig.Networks[0].CIDR = "10.0.10.0/24"
+			newConfig.Networks[1].ID = "20"
+
+			Expect(ValidateInfrastructureConfigUpdate(infrastructureConfig, newConfig, fldPath)).To(ConsistOf(
+				InvalidField("infrastructureConfig.networks[0].cidr"),
+				InvalidField("infrastructureConfig.networks[1].id"),
+			))
+		})
+
+		It("should forbid changing the LoadBalancer prefix", func() {
+			infrastructureConfig.LoadBalancerPrefix = &apismetal.LoadBalancerPrefix{ParentSubnet: "loadbalancer-pool", PrefixLength: 28}
+			newConfig := infrastructureConfig.DeepCopy()
+			newConfig.LoadBalancerPrefix.PrefixLength = 27
+
+			Expect(ValidateInfrastructureConfigUpdate(infrastructureConfig, newConfig, fldPath)).To(ConsistOf(
+				InvalidField("infrastructureConfig.loadBalancerPrefix"),
+			))
+		})
+
+		It("should forbid changing the IPv6 networks and LoadBalancer prefix of dual-stack shoots", func() {
+			infrastructureConfig.IPv6PodsCIDR = "fd00:10:96::/56"
+			infrastructureConfig.IPv6LoadBalancerPrefix = &apismetal.LoadBalancerPrefix{ParentSubnet: "loadbalancer-pool-ipv6", PrefixLength: 120}
+			newConfig := infrastructureConfig.DeepCopy()
+			newConfig.IPv6PodsCIDR = "fd00:10:97::/56"
+			newConfig.IPv6ServicesCIDR = "fd00:10:64::/112"
+			newConfig.IPv6LoadBalancerPrefix = nil
+
+			Expect(ValidateInfrastructureConfigUpdate(infrastructureConfig, newConfig, fldPath)).To(ConsistOf(
+				InvalidField("infrastructureConfig.ipv6PodsCIDR"),
+				InvalidField("infrastructureConfig.ipv6ServicesCIDR"),
+				InvalidField("infrastructureConfig.ipv6LoadBalancerPrefix"),
+			))
+		})
+
+		It("should forbid removing an existing network", func() {
+			newConfig := infrastructureConfig.DeepCopy()
+			newConfig.Networks = newConfig.Networks[1:]
+
+			Expect(ValidateInfrastructureConfigUpdate(infrastructureConfig, newConfig, fldPath)).To(ConsistOf(
+				SimpleMatchField(field.ErrorTypeForbidden, "infrastructureConfig.networks"),
+			))
+		})
+	})
+})
@@ -12,8 +12,17 @@ import (
 	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/metal"
 )
 
-// ValidateCloudProviderSecret checks whether the given secret contains a valid metal service account.
+// ValidateCloudProviderSecret checks whether the given secret contains a valid metal service account. A secret
+// which requests a tenant bootstrapped by the extension does not need to contain one.
 func ValidateCloudProviderSecret(secret *corev1.Secret) error {
+	if tenantBootstrap, ok := secret.Data[metal.TenantBootstrapFieldName]; ok {
+		if string(tenantBootstrap) != "true" && string(tenantBootstrap) != "false" {
+			return fmt.Errorf("invalid field: %s in cloud provider secret, must be true or false", metal.TenantBootstrapFieldName)
+		}
+		if string(tenantBootstrap) == "true" {
+			return nil
+		}
+	}
 	if _, ok := secret.Data[metal.TokenFieldName]; !ok {
 		return fmt.Errorf("missing field: %s in cloud provider secret", metal.TokenFieldName)
 	}
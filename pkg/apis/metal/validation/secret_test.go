@@ -47,5 +47,14 @@ var _ = Describe("Secret validation", func() {
 				"username":  []byte("admin"),
 			},
 			Not(HaveOccurred())),
+		Entry("should return no error if the secret requests a bootstrapped tenant",
+			map[string][]byte{
+				"tenantBootstrap": []byte("true"),
+			},
+			Not(HaveOccurred())),
+		Entry("should return an error if the tenant bootstrap field is invalid",
+			map[string][]byte{
+				"tenantBootstrap": []byte("yes"),
+			}, HaveOccurred()),
 	)
 })
@@ -9,6 +9,8 @@ import (
 	validationutils "github.com/gardener/gardener/pkg/utils/validation"
 	apivalidation "k8s.io/apimachinery/pkg/api/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	apismetal "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal"
 )
 
 // ValidateNetworking validates the network settings of a Shoot.
@@ -22,15 +24,19 @@ func ValidateNetworking(networking *core.Networking, fldPath *field.Path) field.
 	return allErrs
 }
 
-// ValidateWorkers validates the workers of a Shoot.
-func ValidateWorkers(workers []core.Worker, fldPath *field.Path) field.ErrorList {
+// ValidateWorkers validates the workers of a Shoot. The workerConfigs contain the decoded WorkerConfigs of the workers
+// at the same index, a worker with a storage layout in its WorkerConfig does not need a volume.
+func ValidateWorkers(workers []core.Worker, workerConfigs []*apismetal.WorkerConfig, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
 	for i, worker := range workers {
 		workerFldPath := fldPath.Index(i)
 
+		hasStorage := i < len(workerConfigs) && workerConfigs[i] != nil && workerConfigs[i].Storage != nil
 		if worker.Volume == nil {
-			allErrs = append(allErrs, field.Required(workerFldPath.Child("volume"), "must not be nil"))
+			if !hasStorage {
+				allErrs = append(allErrs, field.Required(workerFldPath.Child("volume"), "must not be nil unless a storage layout is configured in the providerConfig"))
+			}
 		} else {
 			allErrs = append(allErrs, validateVolume(worker.Volume, workerFldPath.Child("volume"))...)
 		}
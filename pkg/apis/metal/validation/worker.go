@@ -0,0 +1,306 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+	"text/template"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	apismetal "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal"
+)
+
+// ValidateWorkerConfig validates a WorkerConfig object.
+func ValidateWorkerConfig(workerConfig *apismetal.WorkerConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	allErrs = append(allErrs, metav1validation.ValidateLabels(workerConfig.ExtraServerLabels, fldPath.Child("extraServerLabels"))...)
+	if workerConfig.ServerSelector != nil {
+		allErrs = append(allErrs, metav1validation.ValidateLabelSelector(workerConfig.ServerSelector, metav1validation.LabelSelectorValidationOptions{}, fldPath.Child("serverSelector"))...)
+	}
+
+	allErrs = append(allErrs, validateIPAMConfig(workerConfig.IPAMConfig, fldPath.Child("ipamConfig"))...)
+	zones := sets.New[string]()
+	for i, zoneConfig := range workerConfig.ZoneIPAMConfigs {
+		zonePath := fldPath.Child("zoneIPAMConfigs").Index(i)
+		if zoneConfig.Zone == "" {
+			allErrs = append(allErrs, field.Required(zonePath.Child("zone"), "zone is required"))
+		} else if zones.Has(zoneConfig.Zone) {
+			allErrs = append(allErrs, field.Duplicate(zonePath.Child("zone"), zoneConfig.Zone))
+		}
+		zones.Insert(zoneConfig.Zone)
+		allErrs = append(allErrs, validateIPAMConfig(zoneConfig.IPAMConfig, zonePath.Child("ipamConfig"))...)
+	}
+	if workerConfig.ServerSettings != nil {
+		allErrs = append(allErrs, validateServerSettings(workerConfig.ServerSettings, fldPath.Child("serverSettings"))...)
+	}
+	if workerConfig.Storage != nil {
+		allErrs = append(allErrs, validateStorageConfig(workerConfig.Storage, fldPath.Child("storage"))...)
+	}
+	if workerConfig.HostnamePolicy != nil {
+		allErrs = append(allErrs, ValidateHostnamePolicy(workerConfig.HostnamePolicy, fldPath.Child("hostnamePolicy"))...)
+	}
+	if workerConfig.UserDataCompression != nil && !supportedUserDataCompressions.Has(*workerConfig.UserDataCompression) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("userDataCompression"), *workerConfig.UserDataCompression, sets.List(supportedUserDataCompressions)))
+	}
+	if workerConfig.ReleasePolicy != nil && !supportedReleasePolicies.Has(*workerConfig.ReleasePolicy) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("releasePolicy"), *workerConfig.ReleasePolicy, sets.List(supportedReleasePolicies)))
+	}
+
+	return allErrs
+}
+
+var (
+	supportedUserDataCompressions = sets.New(apismetal.UserDataCompressionNone, apismetal.UserDataCompressionGzip)
+	supportedReleasePolicies      = sets.New(apismetal.ReleasePolicyKeep, apismetal.ReleasePolicyWipe, apismetal.ReleasePolicyRetain)
+)
+
+// ValidateWorkerConfigZones validates that the zones referenced by a WorkerConfig are zones of its worker pool.
+func ValidateWorkerConfigZones(workerConfig *apismetal.WorkerConfig, zones []string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for i, zoneConfig := range workerConfig.ZoneIPAMConfigs {
+		if zoneConfig.Zone != "" && !slices.Contains(zones, zoneConfig.Zone) {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("zoneIPAMConfigs").Index(i).Child("zone"), zoneConfig.Zone, zones))
+		}
+	}
+
+	return allErrs
+}
+
+func validateIPAMConfig(ipamConfig []apismetal.IPAMConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	metadataKeys := sets.New[string]()
+	for i, config := range ipamConfig {
+		configPath := fldPath.Index(i)
+		if config.MetadataKey == "" {
+			allErrs = append(allErrs, field.Required(configPath.Child("metadataKey"), "metadata key is required"))
+		} else if metadataKeys.Has(config.MetadataKey) {
+			allErrs = append(allErrs, field.Duplicate(configPath.Child("metadataKey"), config.MetadataKey))
+		}
+		metadataKeys.Insert(config.MetadataKey)
+
+		if config.IPAMRef == nil {
+			allErrs = append(allErrs, field.Required(configPath.Child("ipamRef"), "ipam reference is required"))
+			continue
+		}
+		if config.IPAMRef.Name == "" {
+			allErrs = append(allErrs, field.Required(configPath.Child("ipamRef", "name"), "name is required"))
+		}
+		if config.IPAMRef.Kind == "" {
+			allErrs = append(allErrs, field.Required(configPath.Child("ipamRef", "kind"), "kind is required"))
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateStorageConfigDisks validates that the storage layout of a WorkerConfig does not use more disks than the
+// Servers of the machine type of its worker pool have.
+func ValidateStorageConfigDisks(storage *apismetal.StorageConfig, diskCount int, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if len(storage.Disks) > diskCount {
+		allErrs = append(allErrs, field.TooMany(fldPath.Child("disks"), len(storage.Disks), diskCount))
+	}
+
+	return allErrs
+}
+
+var (
+	supportedRAIDLevels = map[apismetal.RAIDLevel]int{
+		apismetal.RAIDLevel0:  2,
+		apismetal.RAIDLevel1:  2,
+		apismetal.RAIDLevel5:  3,
+		apismetal.RAIDLevel6:  4,
+		apismetal.RAIDLevel10: 4,
+	}
+	supportedFilesystemFormats = sets.New(apismetal.FilesystemFormatExt4, apismetal.FilesystemFormatXFS)
+)
+
+func validateStorageConfig(storage *apismetal.StorageConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	devices, partitionLabels := sets.New[string](), sets.New[string]()
+	for i, disk := range storage.Disks {
+		diskPath := fldPath.Child("disks").Index(i)
+		allErrs = append(allErrs, validateDevicePath(disk.Device, devices, diskPath.Child("device"))...)
+		for j, partition := range disk.Partitions {
+			partitionPath := diskPath.Child("partitions").Index(j)
+			if partition.Label == "" {
+				allErrs = append(allErrs, field.Required(partitionPath.Child("label"), "label is required"))
+			} else if partitionLabels.Has(partition.Label) {
+				allErrs = append(allErrs, field.Duplicate(partitionPath.Child("label"), partition.Label))
+			}
+			partitionLabels.Insert(partition.Label)
+
+			if partition.Size == nil {
+				if j != len(disk.Partitions)-1 {
+					allErrs = append(allErrs, field.Required(partitionPath.Child("size"), "only the last partition of a disk may fill the remaining space"))
+				}
+			} else if partition.Size.Cmp(resource.MustParse("1Mi")) < 0 {
+				allErrs = append(allErrs, field.Invalid(partitionPath.Child("size"), partition.Size.String(), "size must be at least 1Mi"))
+			}
+		}
+	}
+
+	raidNames := sets.New[string]()
+	for i, raid := range storage.RAIDs {
+		raidPath := fldPath.Child("raids").Index(i)
+		if raid.Name == "" {
+			allErrs = append(allErrs, field.Required(raidPath.Child("name"), "name is required"))
+		} else if raidNames.Has(raid.Name) {
+			allErrs = append(allErrs, field.Duplicate(raidPath.Child("name"), raid.Name))
+		}
+		raidNames.Insert(raid.Name)
+
+		minDevices, ok := supportedRAIDLevels[raid.Level]
+		if !ok {
+			allErrs = append(allErrs, field.NotSupported(raidPath.Child("level"), raid.Level, sets.List(sets.KeySet(supportedRAIDLevels))))
+		} else if len(raid.Devices) < minDevices {
+			allErrs = append(allErrs, field.Invalid(raidPath.Child("devices"), raid.Devices, fmt.Sprintf("level %s requires at least %d devices", raid.Level, minDevices)))
+		}
+		raidDevices := sets.New[string]()
+		for j, device := range raid.Devices {
+			allErrs = append(allErrs, validateDevicePath(device, raidDevices, raidPath.Child("devices").Index(j))...)
+		}
+		if raid.Spares != nil && *raid.Spares < 0 {
+			allErrs = append(allErrs, field.Invalid(raidPath.Child("spares"), *raid.Spares, "spares must not be negative"))
+		}
+	}
+
+	filesystemDevices, mountPoints := sets.New[string](), sets.New[string]()
+	for i, filesystem := range storage.Filesystems {
+		filesystemPath := fldPath.Child("filesystems").Index(i)
+		allErrs = append(allErrs, validateDevicePath(filesystem.Device, filesystemDevices, filesystemPath.Child("device"))...)
+		if !supportedFilesystemFormats.Has(filesystem.Format) {
+			allErrs = append(allErrs, field.NotSupported(filesystemPath.Child("format"), filesystem.Format, sets.List(supportedFilesystemFormats)))
+		}
+		if filesystem.MountPoint != nil {
+			mountPoint := *filesystem.MountPoint
+			switch {
+			case !path.IsAbs(mountPoint) || path.Clean(mountPoint) != mountPoint:
+				allErrs = append(allErrs, field.Invalid(filesystemPath.Child("mountPoint"), mountPoint, "mount point must be a clean absolute path"))
+			case mountPoint == "/":
+				allErrs = append(allErrs, field.Forbidden(filesystemPath.Child("mountPoint"), "the root filesystem must not be mounted"))
+			case mountPoints.Has(mountPoint):
+				allErrs = append(allErrs, field.Duplicate(filesystemPath.Child("mountPoint"), mountPoint))
+			}
+			mountPoints.Insert(mountPoint)
+		}
+	}
+
+	return allErrs
+}
+
+// validateDevicePath validates that the given device is a unique path below /dev.
+func validateDevicePath(device string, devices sets.Set[string], fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	switch {
+	case device == "":
+		allErrs = append(allErrs, field.Required(fldPath, "device is required"))
+	case !strings.HasPrefix(device, "/dev/") || path.Clean(device) != device:
+		allErrs = append(allErrs, field.Invalid(fldPath, device, "device must be a clean path below /dev"))
+	case devices.Has(device):
+		allErrs = append(allErrs, field.Duplicate(fldPath, device))
+	}
+	devices.Insert(device)
+
+	return allErrs
+}
+
+var (
+	supportedBootModes     = sets.New(apismetal.BootModeUEFI, apismetal.BootModeLegacy)
+	supportedPowerProfiles = sets.New(apismetal.PowerProfilePerformance, apismetal.PowerProfileBalanced, apismetal.PowerProfilePowerSaving)
+)
+
+func validateServerSettings(serverSettings *apismetal.ServerSettings, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for name := range serverSettings.BIOSSettings {
+		if name == "" {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("biosSettings"), name, "BIOS setting name must not be empty"))
+		}
+	}
+	if serverSettings.BootMode != nil && !supportedBootModes.Has(*serverSettings.BootMode) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("bootMode"), *serverSettings.BootMode, sets.List(supportedBootModes)))
+	}
+	if serverSettings.PowerProfile != nil && !supportedPowerProfiles.Has(*serverSettings.PowerProfile) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("powerProfile"), *serverSettings.PowerProfile, sets.List(supportedPowerProfiles)))
+	}
+	if serverSettings.MinimumFirmwareVersion != nil && *serverSettings.MinimumFirmwareVersion == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("minimumFirmwareVersion"), "minimum firmware version must not be empty"))
+	}
+
+	return allErrs
+}
+
+var supportedHostnameSources = sets.New(apismetal.HostnameSourceMachineName, apismetal.HostnameSourceServerName, apismetal.HostnameSourceTemplate)
+
+// hostnameTemplateData contains sample values of the fields a hostname template may refer to. It is used to check
+// that a template renders to a valid hostname.
+var hostnameTemplateData = struct {
+	MachineName string
+	ServerName  string
+}{
+	MachineName: "machine-0",
+	ServerName:  "server-0",
+}
+
+// ValidateHostnamePolicy validates a HostnamePolicy object.
+func ValidateHostnamePolicy(hostnamePolicy *apismetal.HostnamePolicy, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	hostname := hostnameTemplateData.MachineName
+	switch hostnamePolicy.Source {
+	case apismetal.HostnameSourceTemplate:
+		if hostnamePolicy.Template == nil || *hostnamePolicy.Template == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("template"), "template is required for source Template"))
+			break
+		}
+		tmpl, err := template.New("hostname").Option("missingkey=error").Parse(*hostnamePolicy.Template)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("template"), *hostnamePolicy.Template, err.Error()))
+			break
+		}
+		var rendered strings.Builder
+		if err := tmpl.Execute(&rendered, hostnameTemplateData); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("template"), *hostnamePolicy.Template, err.Error()))
+			break
+		}
+		hostname = rendered.String()
+		for _, msg := range validation.IsDNS1123Label(hostname) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("template"), *hostnamePolicy.Template, "rendered hostname is invalid: "+msg))
+		}
+	case apismetal.HostnameSourceMachineName, apismetal.HostnameSourceServerName:
+		if hostnamePolicy.Template != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("template"), "template is only allowed for source Template"))
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("source"), hostnamePolicy.Source, sets.List(supportedHostnameSources)))
+	}
+
+	if hostnamePolicy.Domain != nil {
+		for _, msg := range validation.IsDNS1123Subdomain(*hostnamePolicy.Domain) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("domain"), *hostnamePolicy.Domain, msg))
+		}
+		if len(allErrs) == 0 {
+			for _, msg := range validation.IsFullyQualifiedDomainName(fldPath.Child("domain"), hostname+"."+*hostnamePolicy.Domain) {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("domain"), *hostnamePolicy.Domain, "fully qualified domain name is invalid: "+msg.Detail))
+			}
+		}
+	}
+
+	return allErrs
+}
@@ -0,0 +1,307 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+
+	apismetal "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal"
+)
+
+var _ = Describe("WorkerConfig validation", func() {
+	var fldPath *field.Path
+
+	BeforeEach(func() {
+		fldPath = field.NewPath("providerConfig")
+	})
+
+	Describe("#ValidateWorkerConfig", func() {
+		It("should allow server labels and selectors", func() {
+			workerConfig := &apismetal.WorkerConfig{
+				ExtraServerLabels: map[string]string{"rack": "a"},
+				ServerSelector: &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{
+						{Key: "cpu", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"gen1"}},
+						{Key: "maintenance", Operator: metav1.LabelSelectorOpDoesNotExist},
+					},
+				},
+			}
+
+			Expect(ValidateWorkerConfig(workerConfig, fldPath)).To(BeEmpty())
+		})
+
+		It("should forbid invalid server labels and selectors", func() {
+			workerConfig := &apismetal.WorkerConfig{
+				ExtraServerLabels: map[string]string{"rack": "invalid value"},
+				ServerSelector: &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{
+						{Key: "cpu", Operator: "Equals", Values: []string{"gen1"}},
+					},
+				},
+			}
+
+			Expect(ValidateWorkerConfig(workerConfig, fldPath)).To(ConsistOf(
+				InvalidField("providerConfig.extraServerLabels"),
+				InvalidField("providerConfig.serverSelector.matchExpressions[0].operator"),
+			))
+		})
+
+		It("should allow ipam configs with zone overrides", func() {
+			workerConfig := &apismetal.WorkerConfig{
+				IPAMConfig: []apismetal.IPAMConfig{
+					{MetadataKey: "net", IPAMRef: &apismetal.IPAMObjectReference{Name: "net", APIGroup: "ipam.metal.ironcore.dev", Kind: "Network"}},
+				},
+				ZoneIPAMConfigs: []apismetal.ZoneIPAMConfig{
+					{Zone: "zone1", IPAMConfig: []apismetal.IPAMConfig{
+						{MetadataKey: "net", IPAMRef: &apismetal.IPAMObjectReference{Name: "net-zone1", APIGroup: "ipam.metal.ironcore.dev", Kind: "Network"}},
+					}},
+				},
+			}
+
+			Expect(ValidateWorkerConfig(workerConfig, fldPath)).To(BeEmpty())
+			Expect(ValidateWorkerConfigZones(workerConfig, []string{"zone1", "zone2"}, fldPath)).To(BeEmpty())
+		})
+
+		It("should forbid invalid ipam configs", func() {
+			workerConfig := &apismetal.WorkerConfig{
+				IPAMConfig: []apismetal.IPAMConfig{
+					{MetadataKey: "net", IPAMRef: &apismetal.IPAMObjectReference{Kind: "Network"}},
+					{MetadataKey: "net", IPAMRef: &apismetal.IPAMObjectReference{Name: "net", Kind: "Network"}},
+					{IPAMRef: &apismetal.IPAMObjectReference{Name: "net", Kind: "Network"}},
+				},
+				ZoneIPAMConfigs: []apismetal.ZoneIPAMConfig{
+					{Zone: "zone1", IPAMConfig: []apismetal.IPAMConfig{{MetadataKey: "net"}}},
+					{Zone: "zone1"},
+					{Zone: "zone3"},
+				},
+			}
+
+			Expect(ValidateWorkerConfig(workerConfig, fldPath)).To(ConsistOf(
+				SimpleMatchField(field.ErrorTypeRequired, "providerConfig.ipamConfig[0].ipamRef.name"),
+				SimpleMatchField(field.ErrorTypeDuplicate, "providerConfig.ipamConfig[1].metadataKey"),
+				SimpleMatchField(field.ErrorTypeRequired, "providerConfig.ipamConfig[2].metadataKey"),
+				SimpleMatchField(field.ErrorTypeRequired, "providerConfig.zoneIPAMConfigs[0].ipamConfig[0].ipamRef"),
+				SimpleMatchField(field.ErrorTypeDuplicate, "providerConfig.zoneIPAMConfigs[1].zone"),
+			))
+			Expect(ValidateWorkerConfigZones(workerConfig, []string{"zone1", "zone2"}, fldPath)).To(ConsistOf(
+				SimpleMatchField(field.ErrorTypeNotSupported, "providerConfig.zoneIPAMConfigs[2].zone"),
+			))
+		})
+
+		It("should allow a storage layout", func() {
+			workerConfig := &apismetal.WorkerConfig{
+				Storage: &apismetal.StorageConfig{
+					Disks: []apismetal.DiskConfig{
+						{Device: "/dev/nvme0n1", Partitions: []apismetal.PartitionConfig{
+							{Label: "containerd-0", Size: ptr.To(resource.MustParse("200Gi"))},
+							{Label: "kubelet-0"},
+						}},
+						{Device: "/dev/nvme1n1", Partitions: []apismetal.PartitionConfig{
+							{Label: "containerd-1", Size: ptr.To(resource.MustParse("200Gi"))},
+							{Label: "kubelet-1"},
+						}},
+					},
+					RAIDs: []apismetal.RAIDConfig{
+						{Name: "containerd", Level: apismetal.RAIDLevel1, Devices: []string{"/dev/disk/by-partlabel/containerd-0", "/dev/disk/by-partlabel/containerd-1"}},
+					},
+					Filesystems: []apismetal.FilesystemConfig{
+						{Device: "/dev/md/containerd", Format: apismetal.FilesystemFormatXFS, MountPoint: ptr.To("/var/lib/containerd")},
+						{Device: "/dev/disk/by-partlabel/kubelet-0", Format: apismetal.FilesystemFormatExt4, MountPoint: ptr.To("/var/lib/kubelet")},
+					},
+				},
+			}
+
+			Expect(ValidateWorkerConfig(workerConfig, fldPath)).To(BeEmpty())
+			Expect(ValidateStorageConfigDisks(workerConfig.Storage, 2, fldPath.Child("storage"))).To(BeEmpty())
+			Expect(ValidateStorageConfigDisks(workerConfig.Storage, 1, fldPath.Child("storage"))).To(ConsistOf(
+				SimpleMatchField(field.ErrorTypeTooMany, "providerConfig.storage.disks"),
+			))
+		})
+
+		It("should forbid an invalid storage layout", func() {
+			workerConfig := &apismetal.WorkerConfig{
+				Storage: &apismetal.StorageConfig{
+					Disks: []apismetal.DiskConfig{
+						{Device: "nvme0n1", Partitions: []apismetal.PartitionConfig{
+							{Label: "data"},
+							{Label: "data", Size: ptr.To(resource.MustParse("512Ki"))},
+						}},
+					},
+					RAIDs: []apismetal.RAIDConfig{
+						{Name: "data", Level: apismetal.RAIDLevel5, Devices: []string{"/dev/sda", "/dev/sda"}},
+						{Name: "data", Level: "raid4", Devices: []string{"/dev/sdb", "/dev/sdc"}},
+					},
+					Filesystems: []apismetal.FilesystemConfig{
+						{Device: "/dev/md/data", Format: "btrfs", MountPoint: ptr.To("var/lib/containerd")},
+						{Device: "/dev/md/data", Format: apismetal.FilesystemFormatExt4, MountPoint: ptr.To("/")},
+					},
+				},
+			}
+
+			Expect(ValidateWorkerConfig(workerConfig, fldPath)).To(ConsistOf(
+				InvalidField("providerConfig.storage.disks[0].device"),
+				SimpleMatchField(field.ErrorTypeRequired, "providerConfig.storage.disks[0].partitions[0].size"),
+				SimpleMatchField(field.ErrorTypeDuplicate, "providerConfig.storage.disks[0].partitions[1].label"),
+				InvalidField("providerConfig.storage.disks[0].partitions[1].size"),
+				InvalidField("providerConfig.storage.raids[0].devices"),
+				SimpleMatchField(field.ErrorTypeDuplicate, "providerConfig.storage.raids[0].devices[1]"),
+				SimpleMatchField(field.ErrorTypeDuplicate, "providerConfig.storage.raids[1].name"),
+				SimpleMatchField(field.ErrorTypeNotSupported, "providerConfig.storage.raids[1].level"),
+				SimpleMatchField(field.ErrorTypeNotSupported, "providerConfig.storage.filesystems[0].format"),
+				InvalidField("providerConfig.storage.filesystems[0].mountPoint"),
+				SimpleMatchField(field.ErrorTypeDuplicate, "providerConfig.storage.filesystems[1].device"),
+				SimpleMatchField(field.ErrorTypeForbidden, "providerConfig.storage.filesystems[1].mountPoint"),
+			))
+		})
+
+		It("should allow metadata templates", func() {
+			workerConfig := &apismetal.WorkerConfig{
+				Metadata: map[string]string{
+					"static":   "value",
+					"location": "{{ .Region }}/{{ .Zone }}",
+					"image":    "{{ .MachineImageName }}-{{ .MachineImageVersion }}",
+				},
+			}
+
+			Expect(ValidateWorkerConfig(workerConfig, fldPath)).To(BeEmpty())
+		})
+
+		It("should allow metadata values which are no known placeholders", func() {
+			workerConfig := &apismetal.WorkerConfig{
+				Metadata: map[string]string{
+					"unparsable": "{{ .Zone",
+					"unknown":    "{{ .Rack }}",
+				},
+			}
+
+			Expect(ValidateWorkerConfig(workerConfig, fldPath)).To(BeEmpty())
+		})
+
+		It("should allow supported server settings", func() {
+			workerConfig := &apismetal.WorkerConfig{
+				ServerSettings: &apismetal.ServerSettings{
+					BIOSSettings:           map[string]string{"HyperThreading": "Disabled"},
+					BootMode:               ptr.To(apismetal.BootModeUEFI),
+					PowerProfile:           ptr.To(apismetal.PowerProfilePerformance),
+					MinimumFirmwareVersion: ptr.To("2.10.0"),
+				},
+			}
+
+			Expect(ValidateWorkerConfig(workerConfig, fldPath)).To(BeEmpty())
+		})
+
+		It("should forbid invalid server settings", func() {
+			workerConfig := &apismetal.WorkerConfig{
+				ServerSettings: &apismetal.ServerSettings{
+					BIOSSettings:           map[string]string{"": "Disabled"},
+					BootMode:               ptr.To(apismetal.BootMode("BIOS")),
+					PowerProfile:           ptr.To(apismetal.PowerProfile("Turbo")),
+					MinimumFirmwareVersion: ptr.To(""),
+				},
+			}
+
+			Expect(ValidateWorkerConfig(workerConfig, fldPath)).To(ConsistOf(
+				InvalidField("providerConfig.serverSettings.biosSettings"),
+				SimpleMatchField(field.ErrorTypeNotSupported, "providerConfig.serverSettings.bootMode"),
+				SimpleMatchField(field.ErrorTypeNotSupported, "providerConfig.serverSettings.powerProfile"),
+				SimpleMatchField(field.ErrorTypeRequired, "providerConfig.serverSettings.minimumFirmwareVersion"),
+			))
+		})
+
+		It("should allow supported user data compressions", func() {
+			for _, compression := range []apismetal.UserDataCompression{apismetal.UserDataCompressionNone, apismetal.UserDataCompressionGzip} {
+				workerConfig := &apismetal.WorkerConfig{UserDataCompression: ptr.To(compression)}
+
+				Expect(ValidateWorkerConfig(workerConfig, fldPath)).To(BeEmpty())
+			}
+		})
+
+		It("should forbid unsupported user data compressions", func() {
+			workerConfig := &apismetal.WorkerConfig{UserDataCompression: ptr.To(apismetal.UserDataCompression("Zstd"))}
+
+			Expect(ValidateWorkerConfig(workerConfig, fldPath)).To(ConsistOf(
+				SimpleMatchField(field.ErrorTypeNotSupported, "providerConfig.userDataCompression"),
+			))
+		})
+
+		It("should allow supported release policies", func() {
+			for _, releasePolicy := range []apismetal.ReleasePolicy{apismetal.ReleasePolicyKeep, apismetal.ReleasePolicyWipe, apismetal.ReleasePolicyRetain} {
+				workerConfig := &apismetal.WorkerConfig{ReleasePolicy: ptr.To(releasePolicy)}
+
+				Expect(ValidateWorkerConfig(workerConfig, fldPath)).To(BeEmpty())
+			}
+		})
+
+		It("should forbid unsupported release policies", func() {
+			workerConfig := &apismetal.WorkerConfig{ReleasePolicy: ptr.To(apismetal.ReleasePolicy("Delete"))}
+
+			Expect(ValidateWorkerConfig(workerConfig, fldPath)).To(ConsistOf(
+				SimpleMatchField(field.ErrorTypeNotSupported, "providerConfig.releasePolicy"),
+			))
+		})
+	})
+
+	Describe("#ValidateHostnamePolicy", func() {
+		It("should allow valid hostname policies", func() {
+			Expect(ValidateHostnamePolicy(&apismetal.HostnamePolicy{
+				Source: apismetal.HostnameSourceServerName,
+				Domain: ptr.To("example.com"),
+			}, fldPath)).To(BeEmpty())
+			Expect(ValidateHostnamePolicy(&apismetal.HostnamePolicy{
+				Source:   apismetal.HostnameSourceTemplate,
+				Template: ptr.To("node-{{ .ServerName }}"),
+				Domain:   ptr.To("nodes.example.com"),
+			}, fldPath)).To(BeEmpty())
+		})
+
+		It("should forbid unsupported sources", func() {
+			Expect(ValidateHostnamePolicy(&apismetal.HostnamePolicy{Source: "DNS"}, fldPath)).To(ConsistOf(
+				SimpleMatchField(field.ErrorTypeNotSupported, "providerConfig.source"),
+			))
+		})
+
+		It("should require a template for source Template", func() {
+			Expect(ValidateHostnamePolicy(&apismetal.HostnamePolicy{Source: apismetal.HostnameSourceTemplate}, fldPath)).To(ConsistOf(
+				SimpleMatchField(field.ErrorTypeRequired, "providerConfig.template"),
+			))
+		})
+
+		It("should forbid a template for other sources", func() {
+			Expect(ValidateHostnamePolicy(&apismetal.HostnamePolicy{
+				Source:   apismetal.HostnameSourceMachineName,
+				Template: ptr.To("{{ .MachineName }}"),
+			}, fldPath)).To(ConsistOf(
+				SimpleMatchField(field.ErrorTypeForbidden, "providerConfig.template"),
+			))
+		})
+
+		DescribeTable("should forbid invalid templates",
+			func(template string) {
+				Expect(ValidateHostnamePolicy(&apismetal.HostnamePolicy{
+					Source:   apismetal.HostnameSourceTemplate,
+					Template: ptr.To(template),
+				}, fldPath)).To(ConsistOf(
+					InvalidField("providerConfig.template"),
+				))
+			},
+			Entry("unparsable template", "{{ .MachineName"),
+			Entry("unknown field", "{{ .PoolName }}"),
+			Entry("invalid hostname", "{{ .MachineName }}_{{ .ServerName }}"),
+		)
+
+		It("should forbid invalid domains", func() {
+			Expect(ValidateHostnamePolicy(&apismetal.HostnamePolicy{
+				Source: apismetal.HostnameSourceMachineName,
+				Domain: ptr.To("-example.com"),
+			}, fldPath)).To(ConsistOf(
+				InvalidField("providerConfig.domain"),
+			))
+		})
+	})
+})
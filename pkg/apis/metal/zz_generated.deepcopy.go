@@ -11,6 +11,7 @@ package metal
 
 import (
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -206,6 +207,11 @@ func (in *CloudProfileConfig) DeepCopyInto(out *CloudProfileConfig) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.HostnamePolicy != nil {
+		in, out := &in.HostnamePolicy, &out.HostnamePolicy
+		*out = new(HostnamePolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -262,6 +268,96 @@ func (in *ControlPlaneConfig) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiskConfig) DeepCopyInto(out *DiskConfig) {
+	*out = *in
+	if in.WipeTable != nil {
+		in, out := &in.WipeTable, &out.WipeTable
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Partitions != nil {
+		in, out := &in.Partitions, &out.Partitions
+		*out = make([]PartitionConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiskConfig.
+func (in *DiskConfig) DeepCopy() *DiskConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DiskConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FilesystemConfig) DeepCopyInto(out *FilesystemConfig) {
+	*out = *in
+	if in.Label != nil {
+		in, out := &in.Label, &out.Label
+		*out = new(string)
+		**out = **in
+	}
+	if in.MountPoint != nil {
+		in, out := &in.MountPoint, &out.MountPoint
+		*out = new(string)
+		**out = **in
+	}
+	if in.MountOptions != nil {
+		in, out := &in.MountOptions, &out.MountOptions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.WipeFilesystem != nil {
+		in, out := &in.WipeFilesystem, &out.WipeFilesystem
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilesystemConfig.
+func (in *FilesystemConfig) DeepCopy() *FilesystemConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FilesystemConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostnamePolicy) DeepCopyInto(out *HostnamePolicy) {
+	*out = *in
+	if in.Template != nil {
+		in, out := &in.Template, &out.Template
+		*out = new(string)
+		**out = **in
+	}
+	if in.Domain != nil {
+		in, out := &in.Domain, &out.Domain
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostnamePolicy.
+func (in *HostnamePolicy) DeepCopy() *HostnamePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(HostnamePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IPAMConfig) DeepCopyInto(out *IPAMConfig) {
 	*out = *in
@@ -343,7 +439,19 @@ func (in *InfrastructureConfig) DeepCopyInto(out *InfrastructureConfig) {
 	if in.Networks != nil {
 		in, out := &in.Networks, &out.Networks
 		*out = make([]Networks, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LoadBalancerPrefix != nil {
+		in, out := &in.LoadBalancerPrefix, &out.LoadBalancerPrefix
+		*out = new(LoadBalancerPrefix)
+		**out = **in
+	}
+	if in.IPv6LoadBalancerPrefix != nil {
+		in, out := &in.IPv6LoadBalancerPrefix, &out.IPv6LoadBalancerPrefix
+		*out = new(LoadBalancerPrefix)
+		**out = **in
 	}
 	return
 }
@@ -366,10 +474,69 @@ func (in *InfrastructureConfig) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InfrastructureState) DeepCopyInto(out *InfrastructureState) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	if in.Networks != nil {
+		in, out := &in.Networks, &out.Networks
+		*out = make([]NetworkStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LoadBalancerPrefix != nil {
+		in, out := &in.LoadBalancerPrefix, &out.LoadBalancerPrefix
+		*out = new(LoadBalancerPrefixStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IPv6LoadBalancerPrefix != nil {
+		in, out := &in.IPv6LoadBalancerPrefix, &out.IPv6LoadBalancerPrefix
+		*out = new(LoadBalancerPrefixStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InfrastructureState.
+func (in *InfrastructureState) DeepCopy() *InfrastructureState {
+	if in == nil {
+		return nil
+	}
+	out := new(InfrastructureState)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *InfrastructureState) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InfrastructureStatus) DeepCopyInto(out *InfrastructureStatus) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
+	if in.Networks != nil {
+		in, out := &in.Networks, &out.Networks
+		*out = make([]NetworkStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LoadBalancerPrefix != nil {
+		in, out := &in.LoadBalancerPrefix, &out.LoadBalancerPrefix
+		*out = new(LoadBalancerPrefixStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IPv6LoadBalancerPrefix != nil {
+		in, out := &in.IPv6LoadBalancerPrefix, &out.IPv6LoadBalancerPrefix
+		*out = new(LoadBalancerPrefixStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -417,6 +584,43 @@ func (in *LoadBalancerConfig) DeepCopy() *LoadBalancerConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoadBalancerPrefix) DeepCopyInto(out *LoadBalancerPrefix) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancerPrefix.
+func (in *LoadBalancerPrefix) DeepCopy() *LoadBalancerPrefix {
+	if in == nil {
+		return nil
+	}
+	out := new(LoadBalancerPrefix)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoadBalancerPrefixStatus) DeepCopyInto(out *LoadBalancerPrefixStatus) {
+	*out = *in
+	if in.SubnetRef != nil {
+		in, out := &in.SubnetRef, &out.SubnetRef
+		*out = new(IPAMObjectReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancerPrefixStatus.
+func (in *LoadBalancerPrefixStatus) DeepCopy() *LoadBalancerPrefixStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(LoadBalancerPrefixStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MachineImage) DeepCopyInto(out *MachineImage) {
 	*out = *in
@@ -492,6 +696,11 @@ func (in *MachineType) DeepCopyInto(out *MachineType) {
 			(*out)[key] = val
 		}
 	}
+	if in.ServerSelector != nil {
+		in, out := &in.ServerSelector, &out.ServerSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -526,9 +735,50 @@ func (in *MetallbConfig) DeepCopy() *MetallbConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkStatus) DeepCopyInto(out *NetworkStatus) {
+	*out = *in
+	if in.DNSResolvers != nil {
+		in, out := &in.DNSResolvers, &out.DNSResolvers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NetworkRef != nil {
+		in, out := &in.NetworkRef, &out.NetworkRef
+		*out = new(IPAMObjectReference)
+		**out = **in
+	}
+	if in.SubnetRef != nil {
+		in, out := &in.SubnetRef, &out.SubnetRef
+		*out = new(IPAMObjectReference)
+		**out = **in
+	}
+	if in.IPv6SubnetRef != nil {
+		in, out := &in.IPv6SubnetRef, &out.IPv6SubnetRef
+		*out = new(IPAMObjectReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkStatus.
+func (in *NetworkStatus) DeepCopy() *NetworkStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Networks) DeepCopyInto(out *Networks) {
 	*out = *in
+	if in.DNSResolvers != nil {
+		in, out := &in.DNSResolvers, &out.DNSResolvers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -542,6 +792,53 @@ func (in *Networks) DeepCopy() *Networks {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PartitionConfig) DeepCopyInto(out *PartitionConfig) {
+	*out = *in
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PartitionConfig.
+func (in *PartitionConfig) DeepCopy() *PartitionConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PartitionConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RAIDConfig) DeepCopyInto(out *RAIDConfig) {
+	*out = *in
+	if in.Devices != nil {
+		in, out := &in.Devices, &out.Devices
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Spares != nil {
+		in, out := &in.Spares, &out.Spares
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RAIDConfig.
+func (in *RAIDConfig) DeepCopy() *RAIDConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(RAIDConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RegionConfig) DeepCopyInto(out *RegionConfig) {
 	*out = *in
@@ -550,6 +847,13 @@ func (in *RegionConfig) DeepCopyInto(out *RegionConfig) {
 		*out = make([]byte, len(*in))
 		copy(*out, *in)
 	}
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = make([]ZoneConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -563,6 +867,97 @@ func (in *RegionConfig) DeepCopy() *RegionConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServerSettings) DeepCopyInto(out *ServerSettings) {
+	*out = *in
+	if in.BIOSSettings != nil {
+		in, out := &in.BIOSSettings, &out.BIOSSettings
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.BootMode != nil {
+		in, out := &in.BootMode, &out.BootMode
+		*out = new(BootMode)
+		**out = **in
+	}
+	if in.PowerProfile != nil {
+		in, out := &in.PowerProfile, &out.PowerProfile
+		*out = new(PowerProfile)
+		**out = **in
+	}
+	if in.MinimumFirmwareVersion != nil {
+		in, out := &in.MinimumFirmwareVersion, &out.MinimumFirmwareVersion
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerSettings.
+func (in *ServerSettings) DeepCopy() *ServerSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(ServerSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageConfig) DeepCopyInto(out *StorageConfig) {
+	*out = *in
+	if in.Disks != nil {
+		in, out := &in.Disks, &out.Disks
+		*out = make([]DiskConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RAIDs != nil {
+		in, out := &in.RAIDs, &out.RAIDs
+		*out = make([]RAIDConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Filesystems != nil {
+		in, out := &in.Filesystems, &out.Filesystems
+		*out = make([]FilesystemConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageConfig.
+func (in *StorageConfig) DeepCopy() *StorageConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WipedServer) DeepCopyInto(out *WipedServer) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WipedServer.
+func (in *WipedServer) DeepCopy() *WipedServer {
+	if in == nil {
+		return nil
+	}
+	out := new(WipedServer)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkerConfig) DeepCopyInto(out *WorkerConfig) {
 	*out = *in
@@ -579,6 +974,11 @@ func (in *WorkerConfig) DeepCopyInto(out *WorkerConfig) {
 			(*out)[key] = val
 		}
 	}
+	if in.ServerSelector != nil {
+		in, out := &in.ServerSelector, &out.ServerSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.IPAMConfig != nil {
 		in, out := &in.IPAMConfig, &out.IPAMConfig
 		*out = make([]IPAMConfig, len(*in))
@@ -586,6 +986,13 @@ func (in *WorkerConfig) DeepCopyInto(out *WorkerConfig) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ZoneIPAMConfigs != nil {
+		in, out := &in.ZoneIPAMConfigs, &out.ZoneIPAMConfigs
+		*out = make([]ZoneIPAMConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Metadata != nil {
 		in, out := &in.Metadata, &out.Metadata
 		*out = make(map[string]string, len(*in))
@@ -593,6 +1000,31 @@ func (in *WorkerConfig) DeepCopyInto(out *WorkerConfig) {
 			(*out)[key] = val
 		}
 	}
+	if in.ServerSettings != nil {
+		in, out := &in.ServerSettings, &out.ServerSettings
+		*out = new(ServerSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HostnamePolicy != nil {
+		in, out := &in.HostnamePolicy, &out.HostnamePolicy
+		*out = new(HostnamePolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Storage != nil {
+		in, out := &in.Storage, &out.Storage
+		*out = new(StorageConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UserDataCompression != nil {
+		in, out := &in.UserDataCompression, &out.UserDataCompression
+		*out = new(UserDataCompression)
+		**out = **in
+	}
+	if in.ReleasePolicy != nil {
+		in, out := &in.ReleasePolicy, &out.ReleasePolicy
+		*out = new(ReleasePolicy)
+		**out = **in
+	}
 	return
 }
 
@@ -614,6 +1046,22 @@ func (in *WorkerConfig) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkerPoolHash) DeepCopyInto(out *WorkerPoolHash) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkerPoolHash.
+func (in *WorkerPoolHash) DeepCopy() *WorkerPoolHash {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkerPoolHash)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkerStatus) DeepCopyInto(out *WorkerStatus) {
 	*out = *in
@@ -625,6 +1073,21 @@ func (in *WorkerStatus) DeepCopyInto(out *WorkerStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.WipedServers != nil {
+		in, out := &in.WipedServers, &out.WipedServers
+		*out = make([]WipedServer, len(*in))
+		copy(*out, *in)
+	}
+	if in.WipingPools != nil {
+		in, out := &in.WipingPools, &out.WipingPools
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.WorkerPoolHashes != nil {
+		in, out := &in.WorkerPoolHashes, &out.WorkerPoolHashes
+		*out = make([]WorkerPoolHash, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -645,3 +1108,49 @@ func (in *WorkerStatus) DeepCopyObject() runtime.Object {
 	}
 	return nil
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZoneConfig) DeepCopyInto(out *ZoneConfig) {
+	*out = *in
+	if in.ServerLabels != nil {
+		in, out := &in.ServerLabels, &out.ServerLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneConfig.
+func (in *ZoneConfig) DeepCopy() *ZoneConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ZoneConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZoneIPAMConfig) DeepCopyInto(out *ZoneIPAMConfig) {
+	*out = *in
+	if in.IPAMConfig != nil {
+		in, out := &in.IPAMConfig, &out.IPAMConfig
+		*out = make([]IPAMConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneIPAMConfig.
+func (in *ZoneIPAMConfig) DeepCopy() *ZoneIPAMConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ZoneIPAMConfig)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -70,6 +70,20 @@ func (c *Config) ApplyETCDBackup(etcdBackup *config.ETCDBackup) {
 	*etcdBackup = c.Config.ETCD.Backup
 }
 
+// ApplyTenantBootstrap sets the given tenant bootstrap configuration to that of this Config.
+func (c *Config) ApplyTenantBootstrap(tenantBootstrap *config.TenantBootstrap) {
+	if c.Config.TenantBootstrap != nil {
+		*tenantBootstrap = *c.Config.TenantBootstrap
+	}
+}
+
+// ApplyServerSanitization sets the given server sanitization configuration to that of this Config.
+func (c *Config) ApplyServerSanitization(serverSanitization *config.ServerSanitization) {
+	if c.Config.ServerSanitization != nil {
+		*serverSanitization = *c.Config.ServerSanitization
+	}
+}
+
 // Options initializes empty config.ControllerConfiguration, applies the set values and returns it.
 func (c *Config) Options() config.ControllerConfiguration {
 	var cfg config.ControllerConfiguration
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"net"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	extensionscontroller "github.com/gardener/gardener/extensions/pkg/controller"
@@ -17,6 +18,7 @@ import (
 	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/gardener/gardener/pkg/utils"
 	"github.com/gardener/gardener/pkg/utils/chart"
 	gutil "github.com/gardener/gardener/pkg/utils/gardener"
 	kutil "github.com/gardener/gardener/pkg/utils/kubernetes"
@@ -25,6 +27,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
@@ -34,6 +37,7 @@ import (
 
 	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/charts"
 	apismetal "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal"
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal/helper"
 	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/internal"
 	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/metal"
 )
@@ -198,7 +202,16 @@ func (vp *valuesProvider) GetControlPlaneChartValues(
 		}
 	}
 
-	return getControlPlaneChartValues(cpConfig, cp, cluster, secretsReader, checksums, scaledDown)
+	// The cloud-controller-manager of a bootstrapped tenant has credentials of its own.
+	ccmSecret := &corev1.Secret{}
+	if err := vp.client.Get(ctx, client.ObjectKey{Namespace: cp.Namespace, Name: metal.CloudControllerManagerSecretName}, ccmSecret); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to get secret %s: %w", metal.CloudControllerManagerSecretName, err)
+		}
+		ccmSecret = nil
+	}
+
+	return getControlPlaneChartValues(cpConfig, cp, cluster, secretsReader, checksums, ccmSecret, scaledDown)
 }
 
 // GetControlPlaneShootChartValues returns the values for the control plane shoot chart applied by the generic actuator.
@@ -218,7 +231,11 @@ func (vp *valuesProvider) GetControlPlaneShootChartValues(
 			return nil, fmt.Errorf("could not decode providerConfig of controlplane '%s': %w", client.ObjectKeyFromObject(cp), err)
 		}
 	}
-	return vp.getControlPlaneShootChartValues(cluster, cpConfig)
+	infraStatus, err := helper.InfrastructureStatusFromRaw(cp.Spec.InfrastructureProviderStatus)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode infrastructureProviderStatus of controlplane '%s': %w", client.ObjectKeyFromObject(cp), err)
+	}
+	return vp.getControlPlaneShootChartValues(cluster, cpConfig, infraStatus)
 }
 
 // GetControlPlaneShootCRDsChartValues returns the values for the control plane shoot CRDs chart applied by the generic actuator.
@@ -248,12 +265,13 @@ func getControlPlaneChartValues(
 	cluster *extensionscontroller.Cluster,
 	secretsReader secretsmanager.Reader,
 	checksums map[string]string,
+	ccmSecret *corev1.Secret,
 	scaledDown bool,
 ) (
 	map[string]any,
 	error,
 ) {
-	ccm, err := getCCMChartValues(cpConfig, cp, cluster, secretsReader, checksums, scaledDown)
+	ccm, err := getCCMChartValues(cpConfig, cp, cluster, secretsReader, checksums, ccmSecret, scaledDown)
 	if err != nil {
 		return nil, err
 	}
@@ -266,13 +284,15 @@ func getControlPlaneChartValues(
 	}, nil
 }
 
-// getCCMChartValues collects and returns the CCM chart values.
+// getCCMChartValues collects and returns the CCM chart values. The CCM uses the credentials of the given secret, if
+// any, and the ones of the cloudprovider secret otherwise.
 func getCCMChartValues(
 	cpConfig *apismetal.ControlPlaneConfig,
 	cp *extensionsv1alpha1.ControlPlane,
 	cluster *extensionscontroller.Cluster,
 	secretsReader secretsmanager.Reader,
 	checksums map[string]string,
+	ccmSecret *corev1.Secret,
 	scaledDown bool,
 ) (map[string]any, error) {
 	serverSecret, found := secretsReader.Get(cloudControllerManagerServerName)
@@ -288,19 +308,27 @@ func getCCMChartValues(
 		podLabels[metal.AllowEgressToIstioIngressLabel] = "allowed"
 	}
 
+	cloudProviderSecretName := v1beta1constants.SecretNameCloudProvider
+	cloudProviderSecretChecksum := checksums[v1beta1constants.SecretNameCloudProvider]
+	if ccmSecret != nil {
+		cloudProviderSecretName = ccmSecret.Name
+		cloudProviderSecretChecksum = utils.ComputeSecretChecksum(ccmSecret.Data)
+	}
+
 	values := map[string]any{
 		"enabled":     true,
 		"replicas":    extensionscontroller.GetControlPlaneReplicas(cluster, scaledDown, 1),
 		"clusterName": cp.Namespace,
 		"podNetwork":  strings.Join(extensionscontroller.GetPodNetwork(cluster), ","),
 		"podAnnotations": map[string]any{
-			"checksum/config-" + internal.CloudProviderConfigMapName:      checksums[internal.CloudProviderConfigMapName],
-			"checksum/secret-" + v1beta1constants.SecretNameCloudProvider: checksums[v1beta1constants.SecretNameCloudProvider],
+			"checksum/config-" + internal.CloudProviderConfigMapName: checksums[internal.CloudProviderConfigMapName],
+			"checksum/secret-" + cloudProviderSecretName:             cloudProviderSecretChecksum,
 		},
 		"podLabels":       podLabels,
 		"tlsCipherSuites": kutil.TLSCipherSuites,
 		"secrets": map[string]any{
-			"server": serverSecret.Name,
+			"server":        serverSecret.Name,
+			"cloudProvider": cloudProviderSecretName,
 		},
 	}
 
@@ -344,17 +372,17 @@ func isOverlayEnabled(networking *gardencorev1beta1.Networking) (bool, error) {
 }
 
 // getControlPlaneShootChartValues collects and returns the control plane shoot chart values.
-func (vp *valuesProvider) getControlPlaneShootChartValues(cluster *extensionscontroller.Cluster, cp *apismetal.ControlPlaneConfig) (map[string]any, error) {
+func (vp *valuesProvider) getControlPlaneShootChartValues(cluster *extensionscontroller.Cluster, cp *apismetal.ControlPlaneConfig, infraStatus *apismetal.InfrastructureStatus) (map[string]any, error) {
 	if cluster.Shoot == nil {
 		return nil, fmt.Errorf("cluster %s does not contain a shoot object", cluster.ObjectMeta.Name)
 	}
 
-	metallb, err := getMetallbChartValues(cp)
+	metallb, err := getMetallbChartValues(cp, cluster, infraStatus)
 	if err != nil {
 		return nil, err
 	}
 
-	calicoBgp, err := getCalicoBgpChartValues(cp, cluster)
+	calicoBgp, err := getCalicoBgpChartValues(cp, cluster, infraStatus)
 	if err != nil {
 		return nil, err
 	}
@@ -391,9 +419,12 @@ func (vp *valuesProvider) getConfigChartValues(cluster *extensionscontroller.Clu
 	return values, nil
 }
 
-// getMetallbChartValues collects and returns the MetalLB chart values.
+// getMetallbChartValues collects and returns the MetalLB chart values. Without a configured address pool, the
+// LoadBalancer prefix reserved by the infrastructure is used.
 func getMetallbChartValues(
 	cpConfig *apismetal.ControlPlaneConfig,
+	cluster *extensionscontroller.Cluster,
+	infraStatus *apismetal.InfrastructureStatus,
 ) (map[string]any, error) {
 	if cpConfig.LoadBalancerConfig == nil || cpConfig.LoadBalancerConfig.MetallbConfig == nil {
 		return map[string]any{
@@ -401,11 +432,19 @@ func getMetallbChartValues(
 		}, nil
 	}
 
-	for _, cidr := range cpConfig.LoadBalancerConfig.MetallbConfig.IPAddressPool {
+	ipAddressPool := cpConfig.LoadBalancerConfig.MetallbConfig.IPAddressPool
+	if len(ipAddressPool) == 0 {
+		ipAddressPool = reservedLoadBalancerPrefixes(infraStatus)
+	}
+
+	for _, cidr := range ipAddressPool {
 		if err := parseAddressPool(cidr); err != nil {
 			return nil, fmt.Errorf("invalid CIDR %q in pool: %w", cidr, err)
 		}
 	}
+	if err := validateAddressPoolIPFamilies(ipAddressPool, cluster); err != nil {
+		return nil, err
+	}
 
 	return map[string]any{
 		"enabled": true,
@@ -415,14 +454,16 @@ func getMetallbChartValues(
 		"l2Advertisement": map[string]any{
 			"enabled": cpConfig.LoadBalancerConfig.MetallbConfig.EnableL2Advertisement,
 		},
-		"ipAddressPool": cpConfig.LoadBalancerConfig.MetallbConfig.IPAddressPool,
+		"ipAddressPool": ipAddressPool,
 	}, nil
 }
 
-// getCalicoBgpChartValues collects and returns the Calico BGP chart values.
+// getCalicoBgpChartValues collects and returns the Calico BGP chart values. Without configured service
+// LoadBalancer IPs, the LoadBalancer prefix reserved by the infrastructure is used.
 func getCalicoBgpChartValues(
 	cpConfig *apismetal.ControlPlaneConfig,
 	cluster *extensionscontroller.Cluster,
+	infraStatus *apismetal.InfrastructureStatus,
 ) (map[string]any, error) {
 	if cpConfig.LoadBalancerConfig == nil || cpConfig.LoadBalancerConfig.CalicoBgpConfig == nil {
 		return map[string]any{
@@ -446,6 +487,9 @@ func getCalicoBgpChartValues(
 				serviceLbIPs = append(serviceLbIPs, cidr)
 			}
 		}
+		if len(serviceLbIPs) == 0 {
+			serviceLbIPs = reservedLoadBalancerPrefixes(infraStatus)
+		}
 
 		if cpConfig.LoadBalancerConfig.CalicoBgpConfig.ServiceExternalIPs != nil {
 			for _, cidr := range cpConfig.LoadBalancerConfig.CalicoBgpConfig.ServiceExternalIPs {
@@ -529,6 +573,12 @@ func getCalicoBgpChartValues(
 		}
 	}
 
+	for _, pools := range [][]string{serviceLbIPs, serviceExtIPs, serviceClusterIPs} {
+		if err := validateAddressPoolIPFamilies(pools, cluster); err != nil {
+			return nil, err
+		}
+	}
+
 	bgpValues := map[string]any{
 		"enabled":                true,
 		"asNumber":               cpConfig.LoadBalancerConfig.CalicoBgpConfig.ASNumber,
@@ -548,6 +598,21 @@ func getCalicoBgpChartValues(
 	}, nil
 }
 
+// reservedLoadBalancerPrefixes returns the LoadBalancer prefixes reserved by the infrastructure, i.e. an IPv4 and an
+// IPv6 prefix for dual-stack shoots.
+func reservedLoadBalancerPrefixes(infraStatus *apismetal.InfrastructureStatus) []string {
+	if infraStatus == nil {
+		return nil
+	}
+	var prefixes []string
+	for _, prefix := range []*apismetal.LoadBalancerPrefixStatus{infraStatus.LoadBalancerPrefix, infraStatus.IPv6LoadBalancerPrefix} {
+		if prefix != nil && prefix.CIDR != "" {
+			prefixes = append(prefixes, prefix.CIDR)
+		}
+	}
+	return prefixes
+}
+
 func processFilters(filtersConfig []apismetal.BGPFilterRule) ([]map[string]any, error) {
 	var filters []map[string]any
 	for _, filter := range filtersConfig {
@@ -589,3 +654,26 @@ func parseAddressPool(cidr string) error {
 	}
 	return nil
 }
+
+// validateAddressPoolIPFamilies checks that every address pool belongs to one of the IP families of the shoot, so
+// that dual-stack shoots may use IPv4 and IPv6 pools side by side.
+func validateAddressPoolIPFamilies(pools []string, cluster *extensionscontroller.Cluster) error {
+	ipFamilies := metal.ShootIPFamilies(cluster.Shoot)
+	for _, pool := range pools {
+		ipFamily := addressPoolIPFamily(pool)
+		if !slices.Contains(ipFamilies, ipFamily) {
+			return fmt.Errorf("address pool %q of IP family %s does not match the IP families %v of the shoot", pool, ipFamily, ipFamilies)
+		}
+	}
+	return nil
+}
+
+// addressPoolIPFamily returns the IP family of a CIDR or an IP range which has already been parsed successfully.
+func addressPoolIPFamily(pool string) gardencorev1beta1.IPFamily {
+	start, _, _ := strings.Cut(pool, "-")
+	start, _, _ = strings.Cut(start, "/")
+	if ip := net.ParseIP(strings.TrimSpace(start)); ip != nil && ip.To4() == nil {
+		return gardencorev1beta1.IPFamilyIPv6
+	}
+	return gardencorev1beta1.IPFamilyIPv4
+}
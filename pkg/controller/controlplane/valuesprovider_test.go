@@ -11,6 +11,7 @@ import (
 	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/gardener/gardener/pkg/utils"
 	secretsmanager "github.com/gardener/gardener/pkg/utils/secrets/manager"
 	fakesecretsmanager "github.com/gardener/gardener/pkg/utils/secrets/manager/fake"
 	. "github.com/onsi/ginkgo/v2"
@@ -323,7 +324,8 @@ var _ = Describe("Valueprovider Reconcile", func() {
 						"TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305",
 					},
 					"secrets": map[string]any{
-						"server": "cloud-controller-manager-server",
+						"server":        "cloud-controller-manager-server",
+						"cloudProvider": "cloudprovider",
 					},
 					metal.CloudControllerManagerFeatureGatesKeyName: map[string]bool{
 						"CustomResourceValidation": true,
@@ -333,6 +335,42 @@ var _ = Describe("Valueprovider Reconcile", func() {
 				},
 			}))
 		})
+
+		It("should use the credentials of the cloud-controller-manager of a bootstrapped tenant", func(ctx SpecContext) {
+			ccmSecret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: ns.Name,
+					Name:      metal.CloudControllerManagerSecretName,
+				},
+				Data: map[string][]byte{metal.KubeConfigFieldName: []byte("kubeconfig")},
+			}
+			Expect(k8sClient.Create(ctx, ccmSecret)).To(Succeed())
+			DeferCleanup(k8sClient.Delete, ccmSecret)
+
+			cp := &extensionsv1alpha1.ControlPlane{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "control-plane",
+					Namespace: ns.Name,
+				},
+			}
+			cluster := &controller.Cluster{
+				Shoot: &gardencorev1beta1.Shoot{
+					Spec: gardencorev1beta1.ShootSpec{
+						Networking: &gardencorev1beta1.Networking{
+							Pods: ptr.To[string]("10.0.0.0/16"),
+						},
+					},
+				},
+				Seed: &gardencorev1beta1.Seed{},
+			}
+
+			values, err := vp.GetControlPlaneChartValues(ctx, cp, cluster, fakeSecretsManager, map[string]string{}, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(HaveKeyWithValue("cloud-controller-manager", SatisfyAll(
+				HaveKeyWithValue("secrets", HaveKeyWithValue("cloudProvider", metal.CloudControllerManagerSecretName)),
+				HaveKeyWithValue("podAnnotations", HaveKeyWithValue("checksum/secret-"+metal.CloudControllerManagerSecretName, utils.ComputeSecretChecksum(ccmSecret.Data))),
+			)))
+		})
 	})
 
 	Describe("#GetControlPlaneShootChartValues", func() {
@@ -848,6 +886,102 @@ var _ = Describe("Valueprovider Reconcile", func() {
 			}))
 		})
 	})
+
+	Describe("#reservedLoadBalancerPrefixes", func() {
+		var (
+			cluster     *controller.Cluster
+			infraStatus *apismetal.InfrastructureStatus
+		)
+
+		BeforeEach(func() {
+			cluster = &controller.Cluster{
+				Shoot: &gardencorev1beta1.Shoot{
+					Spec: gardencorev1beta1.ShootSpec{
+						Networking: &gardencorev1beta1.Networking{
+							Type: ptr.To(metal.ShootCalicoNetworkType),
+						},
+					},
+				},
+			}
+			infraStatus = &apismetal.InfrastructureStatus{
+				LoadBalancerPrefix: &apismetal.LoadBalancerPrefixStatus{CIDR: "192.168.0.16/28"},
+			}
+		})
+
+		It("should use the reserved prefix as metallb address pool if none is configured", func() {
+			values, err := getMetallbChartValues(&apismetal.ControlPlaneConfig{
+				LoadBalancerConfig: &apismetal.LoadBalancerConfig{MetallbConfig: &apismetal.MetallbConfig{}},
+			}, cluster, infraStatus)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(HaveKeyWithValue("ipAddressPool", []string{"192.168.0.16/28"}))
+		})
+
+		It("should prefer the configured metallb address pool over the reserved prefix", func() {
+			values, err := getMetallbChartValues(&apismetal.ControlPlaneConfig{
+				LoadBalancerConfig: &apismetal.LoadBalancerConfig{MetallbConfig: &apismetal.MetallbConfig{
+					IPAddressPool: []string{"10.10.10.0/24"},
+				}},
+			}, cluster, infraStatus)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(HaveKeyWithValue("ipAddressPool", []string{"10.10.10.0/24"}))
+		})
+
+		It("should use the reserved prefix as calico service LoadBalancer IPs if none are configured", func() {
+			values, err := getCalicoBgpChartValues(&apismetal.ControlPlaneConfig{
+				LoadBalancerConfig: &apismetal.LoadBalancerConfig{CalicoBgpConfig: &apismetal.CalicoBgpConfig{}},
+			}, cluster, infraStatus)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(HaveKeyWithValue("bgp", HaveKeyWithValue("serviceLoadBalancerIPs", []string{"192.168.0.16/28"})))
+		})
+
+		It("should use the reserved IPv4 and IPv6 prefixes of dual-stack shoots", func() {
+			cluster.Shoot.Spec.Networking.IPFamilies = []gardencorev1beta1.IPFamily{gardencorev1beta1.IPFamilyIPv4, gardencorev1beta1.IPFamilyIPv6}
+			infraStatus.IPv6LoadBalancerPrefix = &apismetal.LoadBalancerPrefixStatus{CIDR: "2001:db8:1::/120"}
+
+			values, err := getMetallbChartValues(&apismetal.ControlPlaneConfig{
+				LoadBalancerConfig: &apismetal.LoadBalancerConfig{MetallbConfig: &apismetal.MetallbConfig{}},
+			}, cluster, infraStatus)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(HaveKeyWithValue("ipAddressPool", []string{"192.168.0.16/28", "2001:db8:1::/120"}))
+
+			values, err = getCalicoBgpChartValues(&apismetal.ControlPlaneConfig{
+				LoadBalancerConfig: &apismetal.LoadBalancerConfig{CalicoBgpConfig: &apismetal.CalicoBgpConfig{}},
+			}, cluster, infraStatus)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(HaveKeyWithValue("bgp", HaveKeyWithValue("serviceLoadBalancerIPs", []string{"192.168.0.16/28", "2001:db8:1::/120"})))
+		})
+	})
+
+	Describe("#validateAddressPoolIPFamilies", func() {
+		var cluster *controller.Cluster
+
+		BeforeEach(func() {
+			cluster = &controller.Cluster{
+				Shoot: &gardencorev1beta1.Shoot{
+					Spec: gardencorev1beta1.ShootSpec{
+						Networking: &gardencorev1beta1.Networking{},
+					},
+				},
+			}
+		})
+
+		It("should only allow IPv4 pools for shoots without IP families", func() {
+			Expect(validateAddressPoolIPFamilies([]string{"10.10.10.0/24", "10.20.20.10-10.20.20.30"}, cluster)).To(Succeed())
+			Expect(validateAddressPoolIPFamilies([]string{"2001:db8::/64"}, cluster)).NotTo(Succeed())
+		})
+
+		It("should allow IPv4 and IPv6 pools for dual-stack shoots", func() {
+			cluster.Shoot.Spec.Networking.IPFamilies = []gardencorev1beta1.IPFamily{gardencorev1beta1.IPFamilyIPv4, gardencorev1beta1.IPFamilyIPv6}
+
+			Expect(validateAddressPoolIPFamilies([]string{"10.10.10.0/24", "2001:db8::/64", "2001:db8:1::10-2001:db8:1::20"}, cluster)).To(Succeed())
+		})
+
+		It("should forbid IPv4 pools for IPv6-only shoots", func() {
+			cluster.Shoot.Spec.Networking.IPFamilies = []gardencorev1beta1.IPFamily{gardencorev1beta1.IPFamilyIPv6}
+
+			Expect(validateAddressPoolIPFamilies([]string{"2001:db8::/64", "10.10.10.0/24"}, cluster)).To(MatchError(ContainSubstring("10.10.10.0/24")))
+		})
+	})
 })
 
 func encode(obj runtime.Object) []byte {
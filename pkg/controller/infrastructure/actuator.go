@@ -5,17 +5,25 @@ package infrastructure
 
 import (
 	"github.com/gardener/gardener/extensions/pkg/controller/infrastructure"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/config"
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/metal"
 )
 
 type actuator struct {
-	client client.Client
+	client          client.Client
+	recorder        record.EventRecorder
+	tenantBootstrap config.TenantBootstrap
 }
 
 // NewActuator creates a new infrastructure.Actuator.
-func NewActuator(mgr manager.Manager) infrastructure.Actuator {
+func NewActuator(mgr manager.Manager, tenantBootstrap config.TenantBootstrap) infrastructure.Actuator {
 	return &actuator{
-		client: mgr.GetClient(),
+		client:          mgr.GetClient(),
+		recorder:        mgr.GetEventRecorderFor(metal.ProviderName + "-infrastructure"),
+		tenantBootstrap: tenantBootstrap,
 	}
 }
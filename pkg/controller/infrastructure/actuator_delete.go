@@ -5,18 +5,83 @@ package infrastructure
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/gardener/gardener/extensions/pkg/controller"
 	extensionscontroller "github.com/gardener/gardener/extensions/pkg/controller"
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	reconcilerutils "github.com/gardener/gardener/pkg/controllerutils/reconciler"
 	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/metal"
 )
 
+// releaseRequeueInterval is the interval in which the deletion checks whether the claims and allocations of the
+// cluster have been released.
+const releaseRequeueInterval = 10 * time.Second
+
+// allocationGVKs are the kinds of objects which claim servers or addresses in the metal cluster on behalf of the cluster.
+var allocationGVKs = []schema.GroupVersionKind{metal.ServerClaimGVK, metal.IPGVK}
+
 // Delete implements infrastructure.Actuator.
 func (a *actuator) Delete(ctx context.Context, log logr.Logger, infra *extensionsv1alpha1.Infrastructure, cluster *controller.Cluster) error {
-	return nil
+	return a.delete(ctx, log, infra, cluster, false)
 }
 
+// ForceDelete implements infrastructure.Actuator.
 func (a *actuator) ForceDelete(ctx context.Context, log logr.Logger, infra *extensionsv1alpha1.Infrastructure, cluster *extensionscontroller.Cluster) error {
-	return a.Delete(ctx, log, infra, cluster)
+	return a.delete(ctx, log, infra, cluster, true)
+}
+
+// delete releases all ServerClaims and IPs of the cluster and removes the IPAM objects and a bootstrapped tenant
+// afterwards. Unless forced, the deletion is blocked until every claim and allocation is gone. A forced deletion is
+// best-effort: errors in the metal cluster are logged and the remaining objects are left behind.
+func (a *actuator) delete(ctx context.Context, log logr.Logger, infra *extensionsv1alpha1.Infrastructure, cluster *controller.Cluster, force bool) error {
+	ignoreIfForced := func(err error) error {
+		if err == nil || !force {
+			return err
+		}
+		log.Error(err, "Ignoring error during forced deletion")
+		return nil
+	}
+
+	metalClient, namespace, err := a.getMetalClientAndNamespace(ctx, infra, cluster)
+	if err != nil {
+		return ignoreIfForced(err)
+	}
+
+	for _, gvk := range allocationGVKs {
+		if err := ignoreIfForced(deleteClusterObjects(ctx, log, metalClient, namespace, cluster, gvk)); err != nil {
+			return err
+		}
+	}
+
+	if !force {
+		remaining, err := countClusterObjects(ctx, metalClient, namespace, cluster, allocationGVKs...)
+		if err != nil {
+			return err
+		}
+		if remaining > 0 {
+			return &reconcilerutils.RequeueAfterError{
+				Cause:        fmt.Errorf("waiting for %d ServerClaims and IPs of the cluster to be released", remaining),
+				RequeueAfter: releaseRequeueInterval,
+			}
+		}
+	}
+
+	// Subnets reference their Network, hence they are removed first.
+	if err := ignoreIfForced(deleteClusterObjects(ctx, log, metalClient, namespace, cluster, metal.SubnetGVK)); err != nil {
+		return err
+	}
+	if err := ignoreIfForced(deleteClusterObjects(ctx, log, metalClient, namespace, cluster, metal.NetworkGVK)); err != nil {
+		return err
+	}
+	if err := ignoreIfForced(a.deleteTenant(ctx, log, infra, cluster)); err != nil {
+		return err
+	}
+
+	log.Info("Successfully deleted objects in metal cluster")
+	return nil
 }
@@ -0,0 +1,129 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package infrastructure
+
+import (
+	"encoding/json"
+
+	extensionscontroller "github.com/gardener/gardener/extensions/pkg/controller"
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	. "sigs.k8s.io/controller-runtime/pkg/envtest/komega"
+
+	metalv1alpha1 "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal/v1alpha1"
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/metal"
+)
+
+var _ = Describe("Actuator Delete", func() {
+	var (
+		log            logr.Logger
+		infra          *extensionsv1alpha1.Infrastructure
+		cluster        *extensionscontroller.Cluster
+		act            *actuator
+		metalNamespace string
+	)
+
+	BeforeEach(func(ctx SpecContext) {
+		log = logr.Discard()
+
+		infrastructureConfigRaw, err := json.Marshal(metalv1alpha1.InfrastructureConfig{
+			Networks: []metalv1alpha1.Networks{
+				{Name: "worker-network-1", CIDR: "10.10.10.0/24", ID: "1"},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		infra = &extensionsv1alpha1.Infrastructure{}
+		infra.Name = "infra-to-delete"
+		infra.Namespace = metav1.NamespaceDefault
+		infra.Spec.ProviderConfig = &runtime.RawExtension{
+			Raw: infrastructureConfigRaw,
+		}
+		Expect(k8sClient.Create(ctx, infra)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, infra)
+
+		metalNamespace = SetupCloudProviderSecret(ctx, infra.Namespace).Name
+
+		cluster = &extensionscontroller.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "shoot--foo--delete",
+			},
+		}
+
+		act = &actuator{client: k8sClient}
+		Expect(act.Reconcile(ctx, log, infra, cluster)).To(Succeed())
+	})
+
+	It("should delete the IPAM objects of the cluster in the metal cluster", func(ctx SpecContext) {
+		network := metal.NewUnstructured(metal.NetworkGVK, metalNamespace, "shoot--foo--delete-worker-network-1")
+		subnet := metal.NewUnstructured(metal.SubnetGVK, metalNamespace, "shoot--foo--delete-worker-network-1")
+		Eventually(Get(network)).Should(Succeed())
+		Eventually(Get(subnet)).Should(Succeed())
+
+		Expect(act.Delete(ctx, log, infra, cluster)).To(Succeed())
+
+		Eventually(Get(network)).Should(Satisfy(apierrors.IsNotFound))
+		Eventually(Get(subnet)).Should(Satisfy(apierrors.IsNotFound))
+	})
+
+	It("should block the deletion until the ServerClaims and IPs of the cluster are released", func(ctx SpecContext) {
+		serverClaim := metal.NewUnstructured(metal.ServerClaimGVK, metalNamespace, "machine-0")
+		serverClaim.SetLabels(map[string]string{metal.ClusterNameLabel: cluster.ObjectMeta.Name})
+		serverClaim.SetFinalizers([]string{"metal.ironcore.dev/serverclaim"})
+		Expect(k8sClient.Create(ctx, serverClaim)).To(Succeed())
+
+		ip := metal.NewUnstructured(metal.IPGVK, metalNamespace, "machine-0")
+		ip.SetLabels(map[string]string{metal.ClusterNameLabel: cluster.ObjectMeta.Name})
+		Expect(k8sClient.Create(ctx, ip)).To(Succeed())
+
+		Expect(act.Delete(ctx, log, infra, cluster)).To(MatchError(ContainSubstring("waiting for 1 ServerClaims and IPs")))
+
+		Eventually(Get(ip)).Should(Satisfy(apierrors.IsNotFound))
+		Eventually(Object(serverClaim)).Should(HaveField("ObjectMeta.DeletionTimestamp", Not(BeNil())))
+		subnet := metal.NewUnstructured(metal.SubnetGVK, metalNamespace, "shoot--foo--delete-worker-network-1")
+		Consistently(Get(subnet)).Should(Succeed())
+
+		Eventually(Update(serverClaim, func() {
+			serverClaim.SetFinalizers(nil)
+		})).Should(Succeed())
+		Eventually(Get(serverClaim)).Should(Satisfy(apierrors.IsNotFound))
+
+		Expect(act.Delete(ctx, log, infra, cluster)).To(Succeed())
+		Eventually(Get(subnet)).Should(Satisfy(apierrors.IsNotFound))
+	})
+
+	It("should not wait for the ServerClaims of the cluster on force deletion", func(ctx SpecContext) {
+		serverClaim := metal.NewUnstructured(metal.ServerClaimGVK, metalNamespace, "machine-0")
+		serverClaim.SetLabels(map[string]string{metal.ClusterNameLabel: cluster.ObjectMeta.Name})
+		serverClaim.SetFinalizers([]string{"metal.ironcore.dev/serverclaim"})
+		Expect(k8sClient.Create(ctx, serverClaim)).To(Succeed())
+		DeferCleanup(func(ctx SpecContext) {
+			Eventually(Update(serverClaim, func() {
+				serverClaim.SetFinalizers(nil)
+			})).Should(Succeed())
+		})
+
+		Expect(act.ForceDelete(ctx, log, infra, cluster)).To(Succeed())
+
+		subnet := metal.NewUnstructured(metal.SubnetGVK, metalNamespace, "shoot--foo--delete-worker-network-1")
+		Eventually(Get(subnet)).Should(Satisfy(apierrors.IsNotFound))
+	})
+
+	It("should not fail a force deletion if the metal cluster is unavailable", func(ctx SpecContext) {
+		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: infra.Namespace, Name: v1beta1constants.SecretNameCloudProvider}}
+		Eventually(Update(secret, func() {
+			secret.Data["kubeconfig"] = []byte("invalid")
+		})).Should(Succeed())
+
+		Expect(act.Delete(ctx, log, infra, cluster)).NotTo(Succeed())
+		Expect(act.ForceDelete(ctx, log, infra, cluster)).To(Succeed())
+	})
+})
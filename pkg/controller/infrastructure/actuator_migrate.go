@@ -5,13 +5,52 @@ package infrastructure
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 
 	"github.com/gardener/gardener/extensions/pkg/controller"
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
 	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	metalv1alpha1 "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal/v1alpha1"
 )
 
 // Migrate implements infrastructure.Actuator.
-func (a *actuator) Migrate(ctx context.Context, log logr.Logger, infra *extensionsv1alpha1.Infrastructure, cluster *controller.Cluster) error {
+// The resources in the metal cluster are kept as they are, only their state as recorded in the InfrastructureStatus
+// is persisted in the Infrastructure so that Restore can adopt them on the destination seed. The metal cluster is not
+// accessed, hence the migration does not depend on its availability.
+func (a *actuator) Migrate(ctx context.Context, log logr.Logger, infra *extensionsv1alpha1.Infrastructure, _ *controller.Cluster) error {
+	infrastructureStatus := &metalv1alpha1.InfrastructureStatus{}
+	if infra.Status.ProviderStatus != nil && infra.Status.ProviderStatus.Raw != nil {
+		if err := json.Unmarshal(infra.Status.ProviderStatus.Raw, infrastructureStatus); err != nil {
+			return fmt.Errorf("failed to unmarshal infrastructure status: %w", err)
+		}
+	}
+
+	state := &metalv1alpha1.InfrastructureState{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: metalv1alpha1.SchemeGroupVersion.String(),
+			Kind:       "InfrastructureState",
+		},
+		Networks:               infrastructureStatus.Networks,
+		LoadBalancerPrefix:     infrastructureStatus.LoadBalancerPrefix,
+		IPv6LoadBalancerPrefix: infrastructureStatus.IPv6LoadBalancerPrefix,
+	}
+
+	stateRaw, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to marshal infrastructure state: %w", err)
+	}
+
+	log.V(1).Info("Persisting infrastructure state", "networks", len(state.Networks))
+	originalInfra := infra.DeepCopy()
+	infra.Status.State = &runtime.RawExtension{Raw: stateRaw}
+	if err := a.client.Status().Patch(ctx, infra, client.MergeFrom(originalInfra)); err != nil {
+		return fmt.Errorf("failed to patch infrastructure state: %w", err)
+	}
+
 	return nil
 }
@@ -0,0 +1,95 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package infrastructure
+
+import (
+	"encoding/json"
+
+	extensionscontroller "github.com/gardener/gardener/extensions/pkg/controller"
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	. "sigs.k8s.io/controller-runtime/pkg/envtest/komega"
+
+	metalv1alpha1 "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal/v1alpha1"
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/metal"
+)
+
+var _ = Describe("Actuator Migrate", func() {
+	var (
+		log            logr.Logger
+		infra          *extensionsv1alpha1.Infrastructure
+		cluster        *extensionscontroller.Cluster
+		act            *actuator
+		metalNamespace string
+	)
+
+	BeforeEach(func(ctx SpecContext) {
+		log = logr.Discard()
+
+		infrastructureConfigRaw, err := json.Marshal(metalv1alpha1.InfrastructureConfig{
+			Networks: []metalv1alpha1.Networks{
+				{Name: "worker-network-1", CIDR: "10.10.10.0/24", ID: "1"},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		infra = &extensionsv1alpha1.Infrastructure{}
+		infra.Name = "infra-to-migrate"
+		infra.Namespace = metav1.NamespaceDefault
+		infra.Spec.ProviderConfig = &runtime.RawExtension{
+			Raw: infrastructureConfigRaw,
+		}
+		Expect(k8sClient.Create(ctx, infra)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, infra)
+
+		metalNamespace = SetupCloudProviderSecret(ctx, infra.Namespace).Name
+
+		cluster = &extensionscontroller.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "shoot--foo--migrate",
+			},
+		}
+
+		act = &actuator{client: k8sClient}
+		Expect(act.Reconcile(ctx, log, infra, cluster)).To(Succeed())
+	})
+
+	It("should persist the provisioned networks in the infrastructure state and keep the IPAM objects", func(ctx SpecContext) {
+		Expect(act.Migrate(ctx, log, infra, cluster)).To(Succeed())
+
+		Eventually(Object(infra)).Should(HaveField("Status.State", Not(BeNil())))
+		state := &metalv1alpha1.InfrastructureState{}
+		Expect(json.Unmarshal(infra.Status.State.Raw, state)).To(Succeed())
+		Expect(state.Kind).To(Equal("InfrastructureState"))
+		Expect(state.Networks).To(ConsistOf(
+			HaveField("Name", "worker-network-1"),
+		))
+		Expect(state.Networks[0].ID).To(Equal("1"))
+		Expect(state.Networks[0].SubnetRef).To(HaveField("Name", "shoot--foo--migrate-worker-network-1"))
+
+		Consistently(Get(metal.NewUnstructured(metal.NetworkGVK, metalNamespace, "shoot--foo--migrate-worker-network-1"))).Should(Succeed())
+		Consistently(Get(metal.NewUnstructured(metal.SubnetGVK, metalNamespace, "shoot--foo--migrate-worker-network-1"))).Should(Succeed())
+	})
+	It("should persist the infrastructure state if the metal cluster is not reachable", func(ctx SpecContext) {
+		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: infra.Namespace, Name: v1beta1constants.SecretNameCloudProvider}}
+		Eventually(Update(secret, func() {
+			secret.Data["kubeconfig"] = []byte("invalid")
+		})).Should(Succeed())
+
+		Expect(act.Migrate(ctx, log, infra, cluster)).To(Succeed())
+
+		Eventually(Object(infra)).Should(HaveField("Status.State", Not(BeNil())))
+		state := &metalv1alpha1.InfrastructureState{}
+		Expect(json.Unmarshal(infra.Status.State.Raw, state)).To(Succeed())
+		Expect(state.Networks).To(ConsistOf(
+			HaveField("Name", "worker-network-1"),
+		))
+	})
+})
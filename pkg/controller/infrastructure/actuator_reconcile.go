@@ -7,46 +7,247 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/netip"
+	"strings"
+	"time"
 
 	"github.com/gardener/gardener/extensions/pkg/controller"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	v1beta1helper "github.com/gardener/gardener/pkg/apis/core/v1beta1/helper"
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	reconcilerutils "github.com/gardener/gardener/pkg/controllerutils/reconciler"
 	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	metalv1alpha1 "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal/v1alpha1"
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/metal"
 )
 
+// prefixRequeueInterval is the interval in which the reconciliation checks whether the LoadBalancer prefix has
+// been reserved.
+const prefixRequeueInterval = 5 * time.Second
+
 // Reconcile implements infrastructure actuator reconciliation
 func (a *actuator) Reconcile(ctx context.Context, log logr.Logger, infra *extensionsv1alpha1.Infrastructure, cluster *controller.Cluster) error {
+	return a.reconcile(ctx, log, infra, cluster, nil)
+}
+
+// reconcile provisions the networks of the infrastructure in the metal cluster. If a state is given, the networks
+// recorded in it are adopted with their resolved IDs instead of requesting new ones.
+func (a *actuator) reconcile(ctx context.Context, log logr.Logger, infra *extensionsv1alpha1.Infrastructure, cluster *controller.Cluster, state *metalv1alpha1.InfrastructureState) error {
 	var infrastructureConfig metalv1alpha1.InfrastructureConfig
 	err := json.Unmarshal(infra.Spec.ProviderConfig.Raw, &infrastructureConfig)
 	if err != nil {
-		return fmt.Errorf("failed to unmarshal infrastructure config: %w", err)
+		return v1beta1helper.NewErrorWithCodes(fmt.Errorf("failed to unmarshal infrastructure config: %w", err), gardencorev1beta1.ErrorConfigurationProblem)
+	}
+
+	for _, network := range infrastructureConfig.Networks {
+		if network.Name == "" {
+			return v1beta1helper.NewErrorWithCodes(fmt.Errorf("network name is required"), gardencorev1beta1.ErrorConfigurationProblem)
+		}
 	}
 
 	originalInfra := infra.DeepCopy()
 
-	var newNodes []string
-	if infrastructureConfig.Networks != nil {
-		for _, network := range infrastructureConfig.Networks {
-			if network.Name == "" {
-				return fmt.Errorf("network name is required")
+	// The conditions of the individual steps are persisted even if a step fails, so that users can see where the
+	// reconciliation is stuck.
+	infrastructureStatus, reconcileErr := a.reconcileMetalObjects(ctx, log, infra, cluster, &infrastructureConfig, state)
+	if reconcileErr == nil {
+		infra.Status.ProviderStatus = &runtime.RawExtension{Object: infrastructureStatus}
+
+		if infrastructureConfig.Networks != nil {
+			if infra.Status.Networking == nil {
+				infra.Status.Networking = &extensionsv1alpha1.InfrastructureStatusNetworking{}
 			}
-			newNodes = append(newNodes, network.CIDR)
-		}
-		if infra.Status.Networking == nil {
-			infra.Status.Networking = &extensionsv1alpha1.InfrastructureStatusNetworking{}
+			updateNetworkingStatus(infra.Status.Networking, cluster, &infrastructureConfig)
 		}
-		infra.Status.Networking.Nodes = newNodes
 	}
 
 	if err := a.client.Status().Patch(ctx, infra, client.MergeFrom(originalInfra)); err != nil {
 		return fmt.Errorf("failed to patch infrastructure status: %w", err)
 	}
+	if reconcileErr != nil {
+		return reconcileErr
+	}
 
-	return a.reconcile(ctx, log, infra, cluster)
-}
+	for _, prefixStatus := range []*metalv1alpha1.LoadBalancerPrefixStatus{infrastructureStatus.LoadBalancerPrefix, infrastructureStatus.IPv6LoadBalancerPrefix} {
+		if prefixStatus != nil && prefixStatus.CIDR == "" {
+			return &reconcilerutils.RequeueAfterError{
+				Cause:        fmt.Errorf("waiting for LoadBalancer prefix to be reserved in subnet %s", prefixStatus.SubnetRef.Name),
+				RequeueAfter: prefixRequeueInterval,
+			}
+		}
+	}
 
-func (a *actuator) reconcile(ctx context.Context, log logr.Logger, infra *extensionsv1alpha1.Infrastructure, cluster *controller.Cluster) error {
 	return nil
 }
+
+// reconcileMetalObjects checks the credentials, allocates the subnets and reserves the LoadBalancer prefix of the
+// infrastructure in the metal cluster. The outcome of every step is reported as condition of the infrastructure.
+func (a *actuator) reconcileMetalObjects(ctx context.Context, log logr.Logger, infra *extensionsv1alpha1.Infrastructure, cluster *controller.Cluster, infrastructureConfig *metalv1alpha1.InfrastructureConfig, state *metalv1alpha1.InfrastructureState) (*metalv1alpha1.InfrastructureStatus, error) {
+	infrastructureStatus := &metalv1alpha1.InfrastructureStatus{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: metalv1alpha1.SchemeGroupVersion.String(),
+			Kind:       "InfrastructureStatus",
+		},
+	}
+
+	if len(infrastructureConfig.Networks) == 0 && infrastructureConfig.LoadBalancerPrefix == nil && infrastructureConfig.IPv6LoadBalancerPrefix == nil {
+		if err := a.ensureTenant(ctx, log, infra, cluster); err != nil {
+			return nil, a.failCondition(infra, metal.ConditionTypeCredentialsValid, metal.ReasonCredentialsInvalid, err, gardencorev1beta1.ErrorInfraDependencies)
+		}
+		return infrastructureStatus, nil
+	}
+
+	metalClient, namespace, err := a.checkCredentials(ctx, log, infra, cluster)
+	if err != nil {
+		return nil, a.failCondition(infra, metal.ConditionTypeCredentialsValid, metal.ReasonCredentialsInvalid, err, gardencorev1beta1.ErrorInfraDependencies)
+	}
+	a.updateCondition(infra, metal.ConditionTypeCredentialsValid, gardencorev1beta1.ConditionTrue, metal.ReasonCredentialsValid,
+		fmt.Sprintf("Credentials grant access to namespace %s of the metal cluster", namespace))
+
+	if len(infrastructureConfig.Networks) > 0 {
+		for _, network := range infrastructureConfig.Networks {
+			if network.ID == "" {
+				network.ID = restoredNetworkID(state, network.Name)
+			}
+			log.V(1).Info("Applying IPAM objects for network", "network", network.Name)
+			networkStatus, err := applyNetwork(ctx, metalClient, namespace, cluster, network)
+			if err != nil {
+				return nil, a.failCondition(infra, metal.ConditionTypeSubnetsAllocated, metal.ReasonSubnetAllocationFailed, err, gardencorev1beta1.ErrorInfraDependencies)
+			}
+			infrastructureStatus.Networks = append(infrastructureStatus.Networks, *networkStatus)
+		}
+		a.updateCondition(infra, metal.ConditionTypeSubnetsAllocated, gardencorev1beta1.ConditionTrue, metal.ReasonSubnetsAllocated,
+			fmt.Sprintf("Allocated subnets for %d networks", len(infrastructureConfig.Networks)))
+	}
+
+	var reserved, pending []string
+	for _, lb := range []struct {
+		prefix *metalv1alpha1.LoadBalancerPrefix
+		name   string
+		status **metalv1alpha1.LoadBalancerPrefixStatus
+	}{
+		{infrastructureConfig.LoadBalancerPrefix, loadBalancerPrefixSubnetName(cluster), &infrastructureStatus.LoadBalancerPrefix},
+		{infrastructureConfig.IPv6LoadBalancerPrefix, loadBalancerPrefixSubnetName(cluster) + ipv6SubnetSuffix, &infrastructureStatus.IPv6LoadBalancerPrefix},
+	} {
+		if lb.prefix == nil {
+			continue
+		}
+		log.V(1).Info("Applying IPAM subnet for LoadBalancer prefix", "parentSubnet", lb.prefix.ParentSubnet)
+		prefixStatus, err := applyLoadBalancerPrefix(ctx, metalClient, namespace, cluster, lb.name, lb.prefix)
+		if err != nil {
+			return nil, a.failCondition(infra, metal.ConditionTypeLoadBalancerIPsReserved, metal.ReasonIPReservationFailed, err, gardencorev1beta1.ErrorInfraResourcesDepleted)
+		}
+		if prefixStatus.CIDR == "" {
+			pending = append(pending, fmt.Sprintf("a /%d prefix in subnet %s", lb.prefix.PrefixLength, lb.prefix.ParentSubnet))
+		} else {
+			reserved = append(reserved, prefixStatus.CIDR)
+		}
+		*lb.status = prefixStatus
+	}
+	switch {
+	case len(pending) > 0:
+		a.updateCondition(infra, metal.ConditionTypeLoadBalancerIPsReserved, gardencorev1beta1.ConditionProgressing, metal.ReasonIPReservationPending,
+			fmt.Sprintf("Waiting for IPAM to reserve %s", strings.Join(pending, " and ")))
+	case len(reserved) > 0:
+		a.updateCondition(infra, metal.ConditionTypeLoadBalancerIPsReserved, gardencorev1beta1.ConditionTrue, metal.ReasonIPsReserved,
+			fmt.Sprintf("Reserved LoadBalancer prefix %s", strings.Join(reserved, ", ")))
+	}
+
+	return infrastructureStatus, nil
+}
+
+// checkCredentials bootstraps the tenant of the cluster, if requested, and checks that the credentials are allowed
+// to manage the IPAM objects in the metal namespace.
+func (a *actuator) checkCredentials(ctx context.Context, log logr.Logger, infra *extensionsv1alpha1.Infrastructure, cluster *controller.Cluster) (client.Client, string, error) {
+	if err := a.ensureTenant(ctx, log, infra, cluster); err != nil {
+		return nil, "", err
+	}
+
+	metalClient, namespace, err := a.getMetalClientAndNamespace(ctx, infra, cluster)
+	if err != nil {
+		return nil, "", err
+	}
+
+	for _, resource := range requiredIPAMResources {
+		allowed, err := canCreate(ctx, metalClient, namespace, metal.IPAMGroup, resource)
+		if err != nil {
+			return nil, "", err
+		}
+		if !allowed {
+			return nil, "", apierrors.NewForbidden(schema.GroupResource{Group: metal.IPAMGroup, Resource: resource}, "",
+				fmt.Errorf("credentials are not allowed to create %s.%s in namespace %s", resource, metal.IPAMGroup, namespace))
+		}
+	}
+
+	return metalClient, namespace, nil
+}
+
+// updateNetworkingStatus sets the node, pod and service CIDRs of the networking status. The CIDRs are ordered
+// by the IP families of the shoot. Dual-stack shoots get their IPv6 pod and service CIDRs from the infrastructure
+// config, as the shoot spec only contains the ones of the first IP family.
+func updateNetworkingStatus(status *extensionsv1alpha1.InfrastructureStatusNetworking, cluster *controller.Cluster, infrastructureConfig *metalv1alpha1.InfrastructureConfig) {
+	var shootPods, shootServices string
+	if cluster.Shoot != nil && cluster.Shoot.Spec.Networking != nil {
+		shootPods = ptr.Deref(cluster.Shoot.Spec.Networking.Pods, "")
+		shootServices = ptr.Deref(cluster.Shoot.Spec.Networking.Services, "")
+	}
+
+	var pods, services, nodes []string
+	for _, ipFamily := range metal.ShootIPFamilies(cluster.Shoot) {
+		for _, cidrs := range []struct {
+			shootCIDR string
+			ipv6CIDR  string
+			status    *[]string
+		}{
+			{shootPods, infrastructureConfig.IPv6PodsCIDR, &pods},
+			{shootServices, infrastructureConfig.IPv6ServicesCIDR, &services},
+		} {
+			switch {
+			case cidrs.shootCIDR != "" && cidrIPFamily(cidrs.shootCIDR) == ipFamily:
+				*cidrs.status = append(*cidrs.status, cidrs.shootCIDR)
+			case ipFamily == gardencorev1beta1.IPFamilyIPv6 && cidrs.ipv6CIDR != "":
+				*cidrs.status = append(*cidrs.status, cidrs.ipv6CIDR)
+			}
+		}
+
+		for _, network := range infrastructureConfig.Networks {
+			switch {
+			case ipFamily == gardencorev1beta1.IPFamilyIPv4 && network.CIDR != "":
+				nodes = append(nodes, network.CIDR)
+			case ipFamily == gardencorev1beta1.IPFamilyIPv6 && network.IPv6CIDR != "":
+				nodes = append(nodes, network.IPv6CIDR)
+			}
+		}
+	}
+	status.Pods = pods
+	status.Services = services
+	status.Nodes = nodes
+}
+
+// cidrIPFamily returns the IP family of the given CIDR.
+func cidrIPFamily(cidr string) gardencorev1beta1.IPFamily {
+	if prefix, err := netip.ParsePrefix(cidr); err == nil && prefix.Addr().Is6() {
+		return gardencorev1beta1.IPFamilyIPv6
+	}
+	return gardencorev1beta1.IPFamilyIPv4
+}
+
+// restoredNetworkID returns the ID of the network with the given name recorded in the state, if any.
+func restoredNetworkID(state *metalv1alpha1.InfrastructureState, name string) string {
+	if state == nil {
+		return ""
+	}
+	for _, network := range state.Networks {
+		if network.Name == name {
+			return network.ID
+		}
+	}
+	return ""
+}
@@ -8,14 +8,23 @@ import (
 
 	extensionscontroller "github.com/gardener/gardener/extensions/pkg/controller"
 	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	v1beta1helper "github.com/gardener/gardener/pkg/apis/core/v1beta1/helper"
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
 	"github.com/go-logr/logr"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	. "sigs.k8s.io/controller-runtime/pkg/envtest/komega"
 
 	metalv1alpha1 "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal/v1alpha1"
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/metal"
 )
 
 var (
@@ -25,10 +34,12 @@ var (
 
 var _ = Describe("Actuator Reconcile", func() {
 	var (
-		log     logr.Logger
-		infra   *extensionsv1alpha1.Infrastructure
-		cluster *extensionscontroller.Cluster
-		act     *actuator
+		log            logr.Logger
+		infra          *extensionsv1alpha1.Infrastructure
+		cluster        *extensionscontroller.Cluster
+		act            *actuator
+		recorder       *record.FakeRecorder
+		metalNamespace *corev1.Namespace
 	)
 
 	BeforeEach(func(ctx SpecContext) {
@@ -53,7 +64,12 @@ var _ = Describe("Actuator Reconcile", func() {
 		Expect(k8sClient.Create(ctx, infra)).To(Succeed())
 		DeferCleanup(k8sClient.Delete, infra)
 
+		metalNamespace = SetupCloudProviderSecret(ctx, infra.Namespace)
+
 		cluster = &extensionscontroller.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "shoot--foo--bar",
+			},
 			Shoot: &gardencorev1beta1.Shoot{
 				Spec: gardencorev1beta1.ShootSpec{
 					Kubernetes: gardencorev1beta1.Kubernetes{
@@ -63,7 +79,8 @@ var _ = Describe("Actuator Reconcile", func() {
 			},
 		}
 
-		act = &actuator{client: k8sClient}
+		recorder = record.NewFakeRecorder(10)
+		act = &actuator{client: k8sClient, recorder: recorder}
 	})
 
 	Describe("#Reconcile", func() {
@@ -75,5 +92,207 @@ var _ = Describe("Actuator Reconcile", func() {
 			expectedNodes := []string{"10.10.10.0/24", "10.10.20.0/24"}
 			Expect(infra.Status.Networking.Nodes).To(Equal(expectedNodes))
 		})
+
+		It("should create the IPAM networks and subnets in the metal cluster", func(ctx SpecContext) {
+			Expect(act.Reconcile(ctx, log, infra, cluster)).To(Succeed())
+
+			network := metal.NewUnstructured(metal.NetworkGVK, metalNamespace.Name, "shoot--foo--bar-worker-network-1")
+			Eventually(Object(network)).Should(SatisfyAll(
+				HaveField("Object", HaveKeyWithValue("spec", HaveKeyWithValue("id", "1"))),
+				WithTransform(func(obj client.Object) map[string]string { return obj.GetLabels() },
+					HaveKeyWithValue(metal.ClusterNameLabel, "shoot--foo--bar")),
+			))
+
+			subnet := metal.NewUnstructured(metal.SubnetGVK, metalNamespace.Name, "shoot--foo--bar-worker-network-2")
+			Eventually(Object(subnet)).Should(
+				HaveField("Object", HaveKeyWithValue("spec", SatisfyAll(
+					HaveKeyWithValue("cidr", "10.10.20.0/24"),
+					HaveKeyWithValue("network", HaveKeyWithValue("name", "shoot--foo--bar-worker-network-2")),
+				))),
+			)
+		})
+
+		It("should provision dual-stack networks and report the CIDRs in the order of the IP families", func(ctx SpecContext) {
+			infrastructureConfigRaw, err := json.Marshal(metalv1alpha1.InfrastructureConfig{
+				Networks: []metalv1alpha1.Networks{
+					{Name: "worker-network-1", CIDR: "10.10.10.0/24", IPv6CIDR: "2001:db8:1::/64", ID: "1"},
+				},
+				IPv6PodsCIDR:     "fd00:10:96::/56",
+				IPv6ServicesCIDR: "fd00:10:64::/112",
+			})
+			Expect(err).NotTo(HaveOccurred())
+			infra.Spec.ProviderConfig.Raw = infrastructureConfigRaw
+
+			cluster.Shoot.Spec.Networking = &gardencorev1beta1.Networking{
+				Pods:       ptr.To("100.96.0.0/11"),
+				Services:   ptr.To("100.64.0.0/13"),
+				IPFamilies: []gardencorev1beta1.IPFamily{gardencorev1beta1.IPFamilyIPv6, gardencorev1beta1.IPFamilyIPv4},
+			}
+
+			Expect(act.Reconcile(ctx, log, infra, cluster)).To(Succeed())
+
+			Expect(infra.Status.Networking.Nodes).To(Equal([]string{"2001:db8:1::/64", "10.10.10.0/24"}))
+			Expect(infra.Status.Networking.Pods).To(Equal([]string{"fd00:10:96::/56", "100.96.0.0/11"}))
+			Expect(infra.Status.Networking.Services).To(Equal([]string{"fd00:10:64::/112", "100.64.0.0/13"}))
+
+			subnet := metal.NewUnstructured(metal.SubnetGVK, metalNamespace.Name, "shoot--foo--bar-worker-network-1-ipv6")
+			Eventually(Object(subnet)).Should(
+				HaveField("Object", HaveKeyWithValue("spec", SatisfyAll(
+					HaveKeyWithValue("cidr", "2001:db8:1::/64"),
+					HaveKeyWithValue("network", HaveKeyWithValue("name", "shoot--foo--bar-worker-network-1")),
+				))),
+			)
+		})
+
+		It("should reference an IPAM network which has been provisioned upfront", func(ctx SpecContext) {
+			fabricNetwork := metal.NewUnstructured(metal.NetworkGVK, metalNamespace.Name, "fabric-network")
+			Expect(unstructured.SetNestedField(fabricNetwork.Object, "1", "spec", "id")).To(Succeed())
+			Expect(k8sClient.Create(ctx, fabricNetwork)).To(Succeed())
+			DeferCleanup(k8sClient.Delete, fabricNetwork)
+
+			Expect(act.Reconcile(ctx, log, infra, cluster)).To(Succeed())
+
+			subnet := metal.NewUnstructured(metal.SubnetGVK, metalNamespace.Name, "shoot--foo--bar-worker-network-1")
+			Eventually(Object(subnet)).Should(
+				HaveField("Object", HaveKeyWithValue("spec", HaveKeyWithValue("network", HaveKeyWithValue("name", "fabric-network")))),
+			)
+
+			network := metal.NewUnstructured(metal.NetworkGVK, metalNamespace.Name, "shoot--foo--bar-worker-network-1")
+			Consistently(Get(network)).Should(Satisfy(apierrors.IsNotFound))
+		})
+
+		It("should reserve a LoadBalancer prefix from the parent subnet", func(ctx SpecContext) {
+			parentSubnet := metal.NewUnstructured(metal.SubnetGVK, metalNamespace.Name, "loadbalancer-pool")
+			Expect(unstructured.SetNestedMap(parentSubnet.Object, map[string]any{
+				"cidr":    "192.168.0.0/16",
+				"network": map[string]any{"name": "fabric-network"},
+			}, "spec")).To(Succeed())
+			Expect(k8sClient.Create(ctx, parentSubnet)).To(Succeed())
+			DeferCleanup(k8sClient.Delete, parentSubnet)
+
+			infrastructureConfigRaw, err := json.Marshal(metalv1alpha1.InfrastructureConfig{
+				LoadBalancerPrefix: &metalv1alpha1.LoadBalancerPrefix{ParentSubnet: "loadbalancer-pool", PrefixLength: 28},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			infra.Spec.ProviderConfig.Raw = infrastructureConfigRaw
+
+			Expect(act.Reconcile(ctx, log, infra, cluster)).To(MatchError(ContainSubstring("waiting for LoadBalancer prefix")))
+			Expect(infra.Status.Conditions).To(ContainElement(SatisfyAll(
+				HaveField("Type", gardencorev1beta1.ConditionType(metal.ConditionTypeLoadBalancerIPsReserved)),
+				HaveField("Status", gardencorev1beta1.ConditionProgressing),
+				HaveField("Reason", metal.ReasonIPReservationPending),
+			)))
+
+			subnet := metal.NewUnstructured(metal.SubnetGVK, metalNamespace.Name, "shoot--foo--bar-loadbalancer")
+			Eventually(Object(subnet)).Should(
+				HaveField("Object", HaveKeyWithValue("spec", SatisfyAll(
+					HaveKeyWithValue("prefixBits", BeNumerically("==", 28)),
+					HaveKeyWithValue("parentSubnet", HaveKeyWithValue("name", "loadbalancer-pool")),
+					HaveKeyWithValue("network", HaveKeyWithValue("name", "fabric-network")),
+				))),
+			)
+
+			Expect(unstructured.SetNestedField(subnet.Object, "192.168.0.16/28", "status", "reserved")).To(Succeed())
+			Expect(k8sClient.Status().Update(ctx, subnet)).To(Succeed())
+
+			Expect(act.Reconcile(ctx, log, infra, cluster)).To(Succeed())
+			Expect(infra.Status.Conditions).To(ContainElement(SatisfyAll(
+				HaveField("Type", gardencorev1beta1.ConditionType(metal.ConditionTypeLoadBalancerIPsReserved)),
+				HaveField("Status", gardencorev1beta1.ConditionTrue),
+				HaveField("Message", ContainSubstring("192.168.0.16/28")),
+			)))
+
+			infrastructureStatus := &metalv1alpha1.InfrastructureStatus{}
+			Expect(json.Unmarshal(infra.Status.ProviderStatus.Raw, infrastructureStatus)).To(Succeed())
+			Expect(infrastructureStatus.LoadBalancerPrefix).To(Equal(&metalv1alpha1.LoadBalancerPrefixStatus{
+				CIDR: "192.168.0.16/28",
+				SubnetRef: &metalv1alpha1.IPAMObjectReference{
+					Name:     "shoot--foo--bar-loadbalancer",
+					APIGroup: metal.IPAMGroup,
+					Kind:     metal.SubnetKind,
+				},
+			}))
+		})
+
+		It("should report the reconcile steps as conditions and events", func(ctx SpecContext) {
+			Expect(act.Reconcile(ctx, log, infra, cluster)).To(Succeed())
+
+			Eventually(Object(infra)).Should(HaveField("Status.Conditions", ConsistOf(
+				SatisfyAll(
+					HaveField("Type", gardencorev1beta1.ConditionType(metal.ConditionTypeCredentialsValid)),
+					HaveField("Status", gardencorev1beta1.ConditionTrue),
+					HaveField("Reason", metal.ReasonCredentialsValid),
+				),
+				SatisfyAll(
+					HaveField("Type", gardencorev1beta1.ConditionType(metal.ConditionTypeSubnetsAllocated)),
+					HaveField("Status", gardencorev1beta1.ConditionTrue),
+					HaveField("Reason", metal.ReasonSubnetsAllocated),
+				),
+			)))
+			Expect(recorder.Events).To(Receive(HavePrefix("Normal " + metal.ReasonCredentialsValid)))
+			Expect(recorder.Events).To(Receive(HavePrefix("Normal " + metal.ReasonSubnetsAllocated)))
+		})
+
+		It("should report a missing LoadBalancer parent subnet as configuration problem", func(ctx SpecContext) {
+			infrastructureConfigRaw, err := json.Marshal(metalv1alpha1.InfrastructureConfig{
+				LoadBalancerPrefix: &metalv1alpha1.LoadBalancerPrefix{ParentSubnet: "missing-pool", PrefixLength: 28},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			infra.Spec.ProviderConfig.Raw = infrastructureConfigRaw
+
+			err = act.Reconcile(ctx, log, infra, cluster)
+			Expect(v1beta1helper.ExtractErrorCodes(err)).To(ConsistOf(gardencorev1beta1.ErrorConfigurationProblem))
+
+			Eventually(Object(infra)).Should(HaveField("Status.Conditions", ContainElement(SatisfyAll(
+				HaveField("Type", gardencorev1beta1.ConditionType(metal.ConditionTypeLoadBalancerIPsReserved)),
+				HaveField("Status", gardencorev1beta1.ConditionFalse),
+				HaveField("Reason", metal.ReasonIPReservationFailed),
+				HaveField("Codes", ConsistOf(gardencorev1beta1.ErrorConfigurationProblem)),
+			))))
+			Expect(recorder.Events).To(Receive(HavePrefix("Normal " + metal.ReasonCredentialsValid)))
+			Expect(recorder.Events).To(Receive(HavePrefix("Warning " + metal.ReasonIPReservationFailed)))
+		})
+
+		It("should write the provisioned networks into the infrastructure provider status", func(ctx SpecContext) {
+			Expect(act.Reconcile(ctx, log, infra, cluster)).To(Succeed())
+
+			Eventually(Object(infra)).Should(HaveField("Status.ProviderStatus", Not(BeNil())))
+			infrastructureStatus := &metalv1alpha1.InfrastructureStatus{}
+			Expect(json.Unmarshal(infra.Status.ProviderStatus.Raw, infrastructureStatus)).To(Succeed())
+			Expect(infrastructureStatus.Networks).To(ConsistOf(
+				metalv1alpha1.NetworkStatus{
+					Name:    "worker-network-1",
+					ID:      "1",
+					CIDR:    "10.10.10.0/24",
+					Gateway: "10.10.10.1",
+					NetworkRef: &metalv1alpha1.IPAMObjectReference{
+						Name:     "shoot--foo--bar-worker-network-1",
+						APIGroup: metal.IPAMGroup,
+						Kind:     metal.NetworkKind,
+					},
+					SubnetRef: &metalv1alpha1.IPAMObjectReference{
+						Name:     "shoot--foo--bar-worker-network-1",
+						APIGroup: metal.IPAMGroup,
+						Kind:     metal.SubnetKind,
+					},
+				},
+				metalv1alpha1.NetworkStatus{
+					Name:    "worker-network-2",
+					ID:      "2",
+					CIDR:    "10.10.20.0/24",
+					Gateway: "10.10.20.1",
+					NetworkRef: &metalv1alpha1.IPAMObjectReference{
+						Name:     "shoot--foo--bar-worker-network-2",
+						APIGroup: metal.IPAMGroup,
+						Kind:     metal.NetworkKind,
+					},
+					SubnetRef: &metalv1alpha1.IPAMObjectReference{
+						Name:     "shoot--foo--bar-worker-network-2",
+						APIGroup: metal.IPAMGroup,
+						Kind:     metal.SubnetKind,
+					},
+				},
+			))
+		})
 	})
 })
@@ -5,13 +5,26 @@ package infrastructure
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 
 	"github.com/gardener/gardener/extensions/pkg/controller"
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
 	"github.com/go-logr/logr"
+
+	metalv1alpha1 "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal/v1alpha1"
 )
 
 // Restore implements infrastructure.Actuator.
+// The networks and the LoadBalancer prefix are adopted by reconciling them with the state. The IPs of the cluster
+// keep their cluster name label, as the cluster name does not change on the destination seed.
 func (a *actuator) Restore(ctx context.Context, log logr.Logger, infra *extensionsv1alpha1.Infrastructure, cluster *controller.Cluster) error {
-	return a.reconcile(ctx, log, infra, cluster)
+	state := &metalv1alpha1.InfrastructureState{}
+	if infra.Status.State != nil && infra.Status.State.Raw != nil {
+		if err := json.Unmarshal(infra.Status.State.Raw, state); err != nil {
+			return fmt.Errorf("failed to unmarshal infrastructure state: %w", err)
+		}
+	}
+
+	return a.reconcile(ctx, log, infra, cluster, state)
 }
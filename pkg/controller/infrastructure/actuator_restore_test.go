@@ -0,0 +1,93 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package infrastructure
+
+import (
+	"encoding/json"
+
+	extensionscontroller "github.com/gardener/gardener/extensions/pkg/controller"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	. "sigs.k8s.io/controller-runtime/pkg/envtest/komega"
+
+	metalv1alpha1 "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal/v1alpha1"
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/metal"
+)
+
+var _ = Describe("Actuator Restore", func() {
+	var (
+		log            logr.Logger
+		infra          *extensionsv1alpha1.Infrastructure
+		cluster        *extensionscontroller.Cluster
+		act            *actuator
+		metalNamespace string
+	)
+
+	BeforeEach(func(ctx SpecContext) {
+		log = logr.Discard()
+
+		infrastructureConfigRaw, err := json.Marshal(metalv1alpha1.InfrastructureConfig{
+			Networks: []metalv1alpha1.Networks{
+				{Name: "worker-network-1", CIDR: "10.10.10.0/24"},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		infra = &extensionsv1alpha1.Infrastructure{}
+		infra.Name = "infra-to-restore"
+		infra.Namespace = metav1.NamespaceDefault
+		infra.Spec.ProviderConfig = &runtime.RawExtension{
+			Raw: infrastructureConfigRaw,
+		}
+		Expect(k8sClient.Create(ctx, infra)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, infra)
+
+		stateRaw, err := json.Marshal(metalv1alpha1.InfrastructureState{
+			Networks: []metalv1alpha1.NetworkStatus{
+				{Name: "worker-network-1", ID: "42", CIDR: "10.10.10.0/24"},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		infra.Status.State = &runtime.RawExtension{Raw: stateRaw}
+
+		metalNamespace = SetupCloudProviderSecret(ctx, infra.Namespace).Name
+
+		cluster = &extensionscontroller.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "shoot--foo--restore",
+			},
+		}
+
+		act = &actuator{client: k8sClient}
+	})
+
+	It("should adopt the networks recorded in the infrastructure state with their IDs", func(ctx SpecContext) {
+		Expect(act.Restore(ctx, log, infra, cluster)).To(Succeed())
+
+		network := metal.NewUnstructured(metal.NetworkGVK, metalNamespace, "shoot--foo--restore-worker-network-1")
+		Eventually(Object(network)).Should(
+			HaveField("Object", HaveKeyWithValue("spec", HaveKeyWithValue("id", "42"))),
+		)
+
+		subnet := metal.NewUnstructured(metal.SubnetGVK, metalNamespace, "shoot--foo--restore-worker-network-1")
+		Eventually(Object(subnet)).Should(
+			HaveField("Object", HaveKeyWithValue("spec", HaveKeyWithValue("cidr", "10.10.10.0/24"))),
+		)
+	})
+
+	It("should keep the restored network ID on subsequent reconciliations", func(ctx SpecContext) {
+		Expect(act.Restore(ctx, log, infra, cluster)).To(Succeed())
+		Expect(act.Reconcile(ctx, log, infra, cluster)).To(Succeed())
+
+		network := metal.NewUnstructured(metal.NetworkGVK, metalNamespace, "shoot--foo--restore-worker-network-1")
+		Consistently(Object(network)).Should(
+			HaveField("Object", HaveKeyWithValue("spec", HaveKeyWithValue("id", "42"))),
+		)
+	})
+
+})
@@ -12,6 +12,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/config"
 	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/metal"
 )
 
@@ -28,19 +29,29 @@ type AddOptions struct {
 	IgnoreOperationAnnotation bool
 	// ExtensionClass defines the extension class this extension is responsible for.
 	ExtensionClass extensionsv1alpha1.ExtensionClass
+	// TenantBootstrap is the configuration for bootstrapping a dedicated tenant per shoot in the metal cluster.
+	TenantBootstrap config.TenantBootstrap
 }
 
 // AddToManagerWithOptions adds a controller with the given AddOptions to the given manager.
 // The opts.Reconciler is being set with a newly instantiated actuator.
+// The tokens of bootstrapped tenants are renewed by a controller of their own.
 func AddToManagerWithOptions(ctx context.Context, mgr manager.Manager, opts AddOptions) error {
-	return infrastructure.Add(ctx, mgr, infrastructure.AddArgs{
-		Actuator:          NewActuator(mgr),
+	if err := infrastructure.Add(ctx, mgr, infrastructure.AddArgs{
+		Actuator:          NewActuator(mgr, opts.TenantBootstrap),
 		ConfigValidator:   NewConfigValidator(mgr.GetClient(), log.Log),
 		ControllerOptions: opts.Controller,
 		Predicates:        infrastructure.DefaultPredicates(ctx, mgr, opts.IgnoreOperationAnnotation),
 		Type:              metal.Type,
 		ExtensionClass:    opts.ExtensionClass,
-	})
+	}); err != nil {
+		return err
+	}
+
+	if len(opts.TenantBootstrap.Regions) == 0 {
+		return nil
+	}
+	return addTokenRotationController(mgr, opts)
 }
 
 // AddToManager adds a controller with the default AddOptions.
@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gardener/gardener/extensions/pkg/controller"
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/metal"
+)
+
+// listClusterObjects lists all objects of the given kind in the metal namespace which belong to the cluster.
+// Kinds which are not served by the metal cluster have no objects.
+func listClusterObjects(ctx context.Context, metalClient client.Client, namespace string, cluster *controller.Cluster, gvk schema.GroupVersionKind) ([]unstructured.Unstructured, error) {
+	list := metal.NewUnstructuredList(gvk)
+	if err := metalClient.List(ctx, list, client.InNamespace(namespace), client.MatchingLabels(clusterLabels(cluster))); err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to list %s objects: %w", gvk.Kind, err)
+	}
+	return list.Items, nil
+}
+
+// deleteClusterObjects deletes all objects of the given kind in the metal namespace which belong to the cluster.
+func deleteClusterObjects(ctx context.Context, log logr.Logger, metalClient client.Client, namespace string, cluster *controller.Cluster, gvk schema.GroupVersionKind) error {
+	objs, err := listClusterObjects(ctx, metalClient, namespace, cluster, gvk)
+	if err != nil {
+		return err
+	}
+
+	for _, obj := range objs {
+		if obj.GetDeletionTimestamp() != nil {
+			continue
+		}
+		log.V(1).Info("Deleting object in metal cluster", "kind", gvk.Kind, "name", obj.GetName())
+		if err := metalClient.Delete(ctx, &obj); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete %s %s: %w", gvk.Kind, client.ObjectKeyFromObject(&obj), err)
+		}
+	}
+
+	return nil
+}
+
+// countClusterObjects returns the number of objects of the given kinds in the metal namespace which belong to the cluster.
+func countClusterObjects(ctx context.Context, metalClient client.Client, namespace string, cluster *controller.Cluster, gvks ...schema.GroupVersionKind) (int, error) {
+	count := 0
+	for _, gvk := range gvks {
+		objs, err := listClusterObjects(ctx, metalClient, namespace, cluster, gvk)
+		if err != nil {
+			return 0, err
+		}
+		count += len(objs)
+	}
+	return count, nil
+}
@@ -0,0 +1,56 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package infrastructure
+
+import (
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	v1beta1helper "github.com/gardener/gardener/pkg/apis/core/v1beta1/helper"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/utils/clock"
+)
+
+// errorCodes classifies an error of the metal cluster. Errors which cannot be classified get the given default codes.
+func errorCodes(err error, defaultCodes ...gardencorev1beta1.ErrorCode) []gardencorev1beta1.ErrorCode {
+	switch {
+	case apierrors.IsUnauthorized(err):
+		return []gardencorev1beta1.ErrorCode{gardencorev1beta1.ErrorInfraUnauthenticated}
+	case apierrors.IsForbidden(err):
+		return []gardencorev1beta1.ErrorCode{gardencorev1beta1.ErrorInfraUnauthorized}
+	case apierrors.IsTooManyRequests(err):
+		return []gardencorev1beta1.ErrorCode{gardencorev1beta1.ErrorInfraRateLimitsExceeded}
+	case apierrors.IsNotFound(err):
+		return []gardencorev1beta1.ErrorCode{gardencorev1beta1.ErrorConfigurationProblem}
+	}
+	return defaultCodes
+}
+
+// updateCondition sets the condition of the given type on the infrastructure. A change of the condition status is
+// also recorded as event on the infrastructure.
+func (a *actuator) updateCondition(infra *extensionsv1alpha1.Infrastructure, conditionType gardencorev1beta1.ConditionType, status gardencorev1beta1.ConditionStatus, reason, message string, codes ...gardencorev1beta1.ErrorCode) {
+	clk := clock.RealClock{}
+	condition := v1beta1helper.GetOrInitConditionWithClock(clk, infra.Status.Conditions, conditionType)
+	previousStatus := condition.Status
+
+	condition = v1beta1helper.UpdatedConditionWithClock(clk, condition, status, reason, message, codes...)
+	infra.Status.Conditions = v1beta1helper.MergeConditions(infra.Status.Conditions, condition)
+
+	if a.recorder == nil || previousStatus == status {
+		return
+	}
+	eventType := corev1.EventTypeNormal
+	if status == gardencorev1beta1.ConditionFalse {
+		eventType = corev1.EventTypeWarning
+	}
+	a.recorder.Event(infra, eventType, reason, message)
+}
+
+// failCondition sets the condition of the given type to false and returns the error with the error codes of the
+// condition, so that Gardener can classify it.
+func (a *actuator) failCondition(infra *extensionsv1alpha1.Infrastructure, conditionType gardencorev1beta1.ConditionType, reason string, err error, defaultCodes ...gardencorev1beta1.ErrorCode) error {
+	codes := errorCodes(err, defaultCodes...)
+	a.updateCondition(infra, conditionType, gardencorev1beta1.ConditionFalse, reason, err.Error(), codes...)
+	return v1beta1helper.NewErrorWithCodes(err, codes...)
+}
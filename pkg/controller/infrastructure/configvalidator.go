@@ -5,14 +5,28 @@ package infrastructure
 
 import (
 	"context"
+	"fmt"
+	"net/netip"
 
 	"github.com/gardener/gardener/extensions/pkg/controller/infrastructure"
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
 	"github.com/go-logr/logr"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	api "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal"
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal/helper"
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/metal"
 )
 
+// requiredIPAMResources are the IPAM resources the infrastructure actuator manages in the metal cluster.
+var requiredIPAMResources = []string{"networks", "subnets"}
+
 // configValidator implements ConfigValidator for metal infrastructure resources.
 type configValidator struct {
 	client client.Client
@@ -29,5 +43,142 @@ func NewConfigValidator(client client.Client, logger logr.Logger) infrastructure
 
 // Validate validates the provider config of the given infrastructure resource with the cloud provider.
 func (c *configValidator) Validate(ctx context.Context, infra *extensionsv1alpha1.Infrastructure) field.ErrorList {
-	return field.ErrorList{}
+	allErrs := field.ErrorList{}
+	logger := c.logger.WithValues("infrastructure", client.ObjectKeyFromObject(infra))
+
+	infrastructureConfig, err := helper.InfrastructureConfigFromInfrastructure(infra)
+	if err != nil {
+		allErrs = append(allErrs, field.InternalError(nil, err))
+		return allErrs
+	}
+
+	if len(infrastructureConfig.Networks) == 0 && infrastructureConfig.LoadBalancerPrefix == nil && infrastructureConfig.IPv6LoadBalancerPrefix == nil {
+		return allErrs
+	}
+
+	secret := &corev1.Secret{}
+	if err := c.client.Get(ctx, client.ObjectKey{Namespace: infra.Namespace, Name: v1beta1constants.SecretNameCloudProvider}, secret); err != nil {
+		allErrs = append(allErrs, field.InternalError(nil, fmt.Errorf("failed to get cloudprovider secret: %w", err)))
+		return allErrs
+	}
+	// A bootstrapped tenant gets its permissions from the extension and starts with an empty namespace, hence there
+	// is nothing to validate upfront.
+	if isTenantBootstrapped(secret) {
+		logger.V(1).Info("Skipping validation for bootstrapped tenant")
+		return allErrs
+	}
+
+	metalClient, namespace, err := metal.GetMetalClientAndNamespaceFromCloudProviderSecret(ctx, c.client, infra.Namespace)
+	if err != nil {
+		allErrs = append(allErrs, field.InternalError(nil, fmt.Errorf("failed to get metal client and namespace from cloudprovider secret: %w", err)))
+		return allErrs
+	}
+
+	networksPath := field.NewPath("networks")
+
+	logger.V(1).Info("Validating permissions in the metal cluster", "namespace", namespace)
+	for _, resource := range requiredIPAMResources {
+		allowed, err := canCreate(ctx, metalClient, namespace, metal.IPAMGroup, resource)
+		if err != nil {
+			allErrs = append(allErrs, field.InternalError(networksPath, err))
+			return allErrs
+		}
+		if !allowed {
+			allErrs = append(allErrs, field.Forbidden(networksPath, fmt.Sprintf("credentials are not allowed to create %s.%s in namespace %s", resource, metal.IPAMGroup, namespace)))
+		}
+	}
+
+	for i, network := range infrastructureConfig.Networks {
+		if network.ID == "" {
+			continue
+		}
+
+		// A network ID which does not exist yet is reserved by the Network the actuator creates. An existing Network
+		// is only referenced if it has been provisioned upfront or belongs to this cluster.
+		logger.V(1).Info("Validating network ID", "network", network.Name, "id", network.ID)
+		ipamNetwork, err := findNetworkByID(ctx, metalClient, namespace, network.ID)
+		if err != nil {
+			allErrs = append(allErrs, field.InternalError(networksPath.Index(i).Child("id"), err))
+			continue
+		}
+		if ipamNetwork == nil {
+			continue
+		}
+		if clusterName := ipamNetwork.GetLabels()[metal.ClusterNameLabel]; clusterName != "" && clusterName != infra.Namespace {
+			allErrs = append(allErrs, field.Invalid(networksPath.Index(i).Child("id"), network.ID,
+				fmt.Sprintf("network ID is already used by network %s of another cluster", ipamNetwork.GetName())))
+		}
+	}
+
+	for _, lb := range []struct {
+		prefix *api.LoadBalancerPrefix
+		ipv6   bool
+		path   *field.Path
+	}{
+		{infrastructureConfig.LoadBalancerPrefix, false, field.NewPath("loadBalancerPrefix")},
+		{infrastructureConfig.IPv6LoadBalancerPrefix, true, field.NewPath("ipv6LoadBalancerPrefix")},
+	} {
+		if lb.prefix == nil {
+			continue
+		}
+		parentSubnetPath := lb.path.Child("parentSubnet")
+		logger.V(1).Info("Validating LoadBalancer prefix parent subnet", "parentSubnet", lb.prefix.ParentSubnet)
+		parent := metal.NewUnstructured(metal.SubnetGVK, namespace, lb.prefix.ParentSubnet)
+		if err := metalClient.Get(ctx, client.ObjectKeyFromObject(parent), parent); err != nil {
+			if apierrors.IsNotFound(err) {
+				allErrs = append(allErrs, field.NotFound(parentSubnetPath, lb.prefix.ParentSubnet))
+			} else {
+				allErrs = append(allErrs, field.InternalError(parentSubnetPath, err))
+			}
+			continue
+		}
+		allErrs = append(allErrs, validatePrefixInParentSubnet(lb.prefix, lb.ipv6, parent, lb.path)...)
+	}
+
+	return allErrs
+}
+
+// validatePrefixInParentSubnet validates that a prefix of the given length can be reserved in the parent subnet. The
+// CIDR of the parent subnet is only known once IPAM has reserved it, until then there is nothing to validate.
+func validatePrefixInParentSubnet(prefix *api.LoadBalancerPrefix, ipv6 bool, parent *unstructured.Unstructured, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	cidr, _, _ := unstructured.NestedString(parent.Object, "status", "reserved")
+	if cidr == "" {
+		cidr, _, _ = unstructured.NestedString(parent.Object, "spec", "cidr")
+	}
+	parentPrefix, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		return allErrs
+	}
+
+	if ipv6 && !parentPrefix.Addr().Is6() {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("parentSubnet"), prefix.ParentSubnet, fmt.Sprintf("parent subnet %s is not an IPv6 subnet", cidr)))
+		return allErrs
+	}
+	if int(prefix.PrefixLength) < parentPrefix.Bits() || int(prefix.PrefixLength) > parentPrefix.Addr().BitLen() {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("prefixLength"), prefix.PrefixLength,
+			fmt.Sprintf("must be between %d and %d to fit into parent subnet %s", parentPrefix.Bits(), parentPrefix.Addr().BitLen(), cidr)))
+	}
+
+	return allErrs
+}
+
+// canCreate checks whether the metal client is allowed to create the given resource in the namespace.
+func canCreate(ctx context.Context, metalClient client.Client, namespace, group, resource string) (bool, error) {
+	review := &authorizationv1.SelfSubjectAccessReview{
+		Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: namespace,
+				Verb:      "create",
+				Group:     group,
+				Resource:  resource,
+			},
+		},
+	}
+	if err := metalClient.Create(ctx, review); err != nil {
+		return false, fmt.Errorf("failed to review access to %s.%s: %w", resource, group, err)
+	}
+
+	return review.Status.Allowed, nil
 }
@@ -0,0 +1,148 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package infrastructure
+
+import (
+	"encoding/json"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	metalv1alpha1 "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal/v1alpha1"
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/metal"
+)
+
+var _ = Describe("ConfigValidator", func() {
+	var (
+		infra          *extensionsv1alpha1.Infrastructure
+		validator      *configValidator
+		metalNamespace string
+	)
+
+	BeforeEach(func(ctx SpecContext) {
+		infrastructureConfigRaw, err := json.Marshal(metalv1alpha1.InfrastructureConfig{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: metalv1alpha1.SchemeGroupVersion.String(),
+				Kind:       "InfrastructureConfig",
+			},
+			Networks: []metalv1alpha1.Networks{
+				{Name: "worker-network-1", CIDR: "10.10.10.0/24", ID: "100"},
+				{Name: "worker-network-2", CIDR: "10.10.20.0/24"},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		infra = &extensionsv1alpha1.Infrastructure{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: metav1.NamespaceDefault,
+				Name:      "infra-to-validate",
+			},
+			Spec: extensionsv1alpha1.InfrastructureSpec{
+				DefaultSpec: extensionsv1alpha1.DefaultSpec{
+					ProviderConfig: &runtime.RawExtension{Raw: infrastructureConfigRaw},
+				},
+			},
+		}
+
+		metalNamespace = SetupCloudProviderSecret(ctx, infra.Namespace).Name
+
+		validator = NewConfigValidator(k8sClient, logr.Discard()).(*configValidator)
+	})
+
+	It("should return no errors for a network ID which does not exist yet in the metal cluster", func(ctx SpecContext) {
+		Expect(validator.Validate(ctx, infra)).To(BeEmpty())
+	})
+
+	It("should return an error for a network ID which is used by another cluster", func(ctx SpecContext) {
+		network := metal.NewUnstructured(metal.NetworkGVK, metalNamespace, "shoot--foo--other-worker-network-1")
+		network.SetLabels(map[string]string{metal.ClusterNameLabel: "shoot--foo--other"})
+		Expect(unstructured.SetNestedField(network.Object, "100", "spec", "id")).To(Succeed())
+		Expect(k8sClient.Create(ctx, network)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, network)
+
+		Expect(validator.Validate(ctx, infra)).To(ConsistOf(
+			PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":     Equal(field.ErrorTypeInvalid),
+				"Field":    Equal("networks[0].id"),
+				"BadValue": Equal("100"),
+			})),
+		))
+	})
+
+	It("should return no errors if the referenced network exists in the metal cluster", func(ctx SpecContext) {
+		network := metal.NewUnstructured(metal.NetworkGVK, metalNamespace, "fabric-network")
+		Expect(unstructured.SetNestedField(network.Object, "100", "spec", "id")).To(Succeed())
+		Expect(k8sClient.Create(ctx, network)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, network)
+
+		Expect(validator.Validate(ctx, infra)).To(BeEmpty())
+	})
+
+	It("should return no errors for a configuration without networks", func(ctx SpecContext) {
+		infra.Spec.ProviderConfig = nil
+
+		Expect(validator.Validate(ctx, infra)).To(BeEmpty())
+	})
+
+	It("should return an error for a LoadBalancer prefix parent subnet which does not exist in the metal cluster", func(ctx SpecContext) {
+		infrastructureConfigRaw, err := json.Marshal(metalv1alpha1.InfrastructureConfig{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: metalv1alpha1.SchemeGroupVersion.String(),
+				Kind:       "InfrastructureConfig",
+			},
+			LoadBalancerPrefix: &metalv1alpha1.LoadBalancerPrefix{ParentSubnet: "loadbalancer-pool", PrefixLength: 28},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		infra.Spec.ProviderConfig = &runtime.RawExtension{Raw: infrastructureConfigRaw}
+
+		Expect(validator.Validate(ctx, infra)).To(ConsistOf(
+			PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":     Equal(field.ErrorTypeNotFound),
+				"Field":    Equal("loadBalancerPrefix.parentSubnet"),
+				"BadValue": Equal("loadbalancer-pool"),
+			})),
+		))
+	})
+
+	It("should return errors for LoadBalancer prefixes which do not fit into their parent subnets", func(ctx SpecContext) {
+		parent := metal.NewUnstructured(metal.SubnetGVK, metalNamespace, "loadbalancer-pool")
+		Expect(unstructured.SetNestedMap(parent.Object, map[string]any{
+			"cidr":    "10.20.0.0/24",
+			"network": map[string]any{"name": "fabric-network"},
+		}, "spec")).To(Succeed())
+		Expect(k8sClient.Create(ctx, parent)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, parent)
+
+		infrastructureConfigRaw, err := json.Marshal(metalv1alpha1.InfrastructureConfig{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: metalv1alpha1.SchemeGroupVersion.String(),
+				Kind:       "InfrastructureConfig",
+			},
+			LoadBalancerPrefix:     &metalv1alpha1.LoadBalancerPrefix{ParentSubnet: "loadbalancer-pool", PrefixLength: 20},
+			IPv6LoadBalancerPrefix: &metalv1alpha1.LoadBalancerPrefix{ParentSubnet: "loadbalancer-pool", PrefixLength: 120},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		infra.Spec.ProviderConfig = &runtime.RawExtension{Raw: infrastructureConfigRaw}
+
+		Expect(validator.Validate(ctx, infra)).To(ConsistOf(
+			PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":     Equal(field.ErrorTypeInvalid),
+				"Field":    Equal("loadBalancerPrefix.prefixLength"),
+				"BadValue": BeEquivalentTo(20),
+			})),
+			PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":     Equal(field.ErrorTypeInvalid),
+				"Field":    Equal("ipv6LoadBalancerPrefix.parentSubnet"),
+				"BadValue": Equal("loadbalancer-pool"),
+			})),
+		))
+	})
+})
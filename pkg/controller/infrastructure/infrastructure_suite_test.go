@@ -10,13 +10,16 @@ import (
 	"testing"
 	"time"
 
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
 	gardenerextensionv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
 	machinescheme "github.com/gardener/machine-controller-manager/pkg/client/clientset/versioned/scheme"
 	"github.com/ironcore-dev/controller-utils/modutils"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"go.uber.org/zap/zapcore"
+	corev1 "k8s.io/api/core/v1"
 	apiextensionsscheme "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/scheme"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -45,9 +48,10 @@ func TestInfrastructure(t *testing.T) {
 }
 
 var (
-	testEnv   *envtest.Environment
-	cfg       *rest.Config
-	k8sClient client.Client
+	testEnv    *envtest.Environment
+	cfg        *rest.Config
+	k8sClient  client.Client
+	kubeconfig []byte
 )
 
 var _ = BeforeSuite(func() {
@@ -61,6 +65,7 @@ var _ = BeforeSuite(func() {
 			modutils.Dir("github.com/gardener/machine-controller-manager", "kubernetes", "crds", "machine.sapcloud.io_machines.yaml"),
 			modutils.Dir("github.com/gardener/machine-controller-manager", "kubernetes", "crds", "machine.sapcloud.io_machinesets.yaml"),
 			filepath.Join("..", "..", "..", "example", "20-crd-extensions.gardener.cloud_infrastructures.yaml"),
+			filepath.Join("..", "..", "..", "test", "crds"),
 		},
 		ErrorIfCRDPathMissing: true,
 
@@ -90,8 +95,44 @@ var _ = BeforeSuite(func() {
 	Expect(k8sClient).NotTo(BeNil())
 
 	komega.SetClient(k8sClient)
+
+	user, err := testEnv.AddUser(envtest.User{
+		Name:   "dummy",
+		Groups: []string{"system:authenticated", "system:masters"},
+	}, cfg)
+	Expect(err).NotTo(HaveOccurred())
+
+	kubeconfig, err = user.KubeConfig()
+	Expect(err).NotTo(HaveOccurred())
 })
 
+// SetupCloudProviderSecret creates a cloudprovider secret in the given namespace pointing to a
+// metal namespace in the test environment.
+func SetupCloudProviderSecret(ctx SpecContext, namespace string) *corev1.Namespace {
+	metalNamespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "metal-",
+		},
+	}
+	Expect(k8sClient.Create(ctx, metalNamespace)).To(Succeed())
+	DeferCleanup(k8sClient.Delete, metalNamespace)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      v1beta1constants.SecretNameCloudProvider,
+		},
+		Data: map[string][]byte{
+			"namespace":  []byte(metalNamespace.Name),
+			"kubeconfig": kubeconfig,
+		},
+	}
+	Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+	DeferCleanup(k8sClient.Delete, secret)
+
+	return metalNamespace
+}
+
 var _ = AfterSuite(func() {
 	Expect(testEnv.Stop()).To(Succeed())
 })
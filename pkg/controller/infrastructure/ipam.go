@@ -0,0 +1,218 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+
+	"github.com/gardener/gardener/extensions/pkg/controller"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	metalv1alpha1 "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal/v1alpha1"
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/metal"
+)
+
+const (
+	// ipv6SubnetSuffix is appended to the name of the IPAM Subnet created for the IPv6 CIDR of a network.
+	ipv6SubnetSuffix = "-ipv6"
+	// loadBalancerPrefixName is the name suffix of the IPAM Subnet which reserves the LoadBalancer prefix.
+	loadBalancerPrefixName = "loadbalancer"
+	// subnetStateFailed is the state of an IPAM Subnet whose CIDR could not be reserved.
+	subnetStateFailed = "Failed"
+)
+
+// ipamObjectName returns the name of the IPAM Network and Subnet created for the given network.
+func ipamObjectName(cluster *controller.Cluster, networkName string) string {
+	return fmt.Sprintf("%s-%s", cluster.ObjectMeta.Name, networkName)
+}
+
+func clusterLabels(cluster *controller.Cluster) map[string]string {
+	return map[string]string{
+		metal.ClusterNameLabel: cluster.ObjectMeta.Name,
+	}
+}
+
+// applyNetwork creates or updates the IPAM Network and Subnet for the given network in the metal cluster
+// and returns the resulting network status.
+func applyNetwork(ctx context.Context, metalClient client.Client, namespace string, cluster *controller.Cluster, network metalv1alpha1.Networks) (*metalv1alpha1.NetworkStatus, error) {
+	name := ipamObjectName(cluster, network.Name)
+
+	var ipamNetwork *unstructured.Unstructured
+	if network.ID != "" {
+		existing, err := findNetworkByID(ctx, metalClient, namespace, network.ID)
+		if err != nil {
+			return nil, err
+		}
+		// A network which has been provisioned upfront in the metal cluster is referenced as is.
+		if existing != nil && existing.GetName() != name {
+			ipamNetwork = existing
+		}
+	}
+
+	if ipamNetwork == nil {
+		id := network.ID
+		if id == "" {
+			// The Network keeps the ID it has been created with, e.g. one restored after a control plane migration,
+			// as applying it without the ID would drop the field.
+			existing := metal.NewUnstructured(metal.NetworkGVK, namespace, name)
+			if err := metalClient.Get(ctx, client.ObjectKeyFromObject(existing), existing); err != nil && !apierrors.IsNotFound(err) {
+				return nil, fmt.Errorf("failed to get network %s: %w", client.ObjectKeyFromObject(existing), err)
+			}
+			id, _, _ = unstructured.NestedString(existing.Object, "spec", "id")
+		}
+
+		ipamNetwork = metal.NewUnstructured(metal.NetworkGVK, namespace, name)
+		ipamNetwork.SetLabels(clusterLabels(cluster))
+		if id != "" {
+			if err := unstructured.SetNestedField(ipamNetwork.Object, id, "spec", "id"); err != nil {
+				return nil, err
+			}
+		}
+		if err := metalClient.Patch(ctx, ipamNetwork, client.Apply, client.ForceOwnership, metal.FieldOwner); err != nil {
+			return nil, fmt.Errorf("failed to apply network %s: %w", client.ObjectKeyFromObject(ipamNetwork), err)
+		}
+	}
+
+	status := &metalv1alpha1.NetworkStatus{
+		Name:         network.Name,
+		ID:           networkID(ipamNetwork),
+		DNSResolvers: network.DNSResolvers,
+		NetworkRef: &metalv1alpha1.IPAMObjectReference{
+			Name:     ipamNetwork.GetName(),
+			APIGroup: metal.IPAMGroup,
+			Kind:     metal.NetworkKind,
+		},
+	}
+
+	if network.CIDR != "" {
+		subnetRef, gateway, err := applySubnet(ctx, metalClient, namespace, cluster, name, ipamNetwork.GetName(), network.CIDR, network.Gateway)
+		if err != nil {
+			return nil, err
+		}
+		status.CIDR = network.CIDR
+		status.Gateway = gateway
+		status.SubnetRef = subnetRef
+	}
+
+	if network.IPv6CIDR != "" {
+		subnetRef, gateway, err := applySubnet(ctx, metalClient, namespace, cluster, name+ipv6SubnetSuffix, ipamNetwork.GetName(), network.IPv6CIDR, network.IPv6Gateway)
+		if err != nil {
+			return nil, err
+		}
+		status.IPv6CIDR = network.IPv6CIDR
+		status.IPv6Gateway = gateway
+		status.IPv6SubnetRef = subnetRef
+	}
+
+	return status, nil
+}
+
+// applySubnet creates or updates an IPAM Subnet with the given CIDR in the IPAM Network and returns a reference to
+// it together with the gateway address of the subnet.
+func applySubnet(ctx context.Context, metalClient client.Client, namespace string, cluster *controller.Cluster, name, networkName, cidr, gateway string) (*metalv1alpha1.IPAMObjectReference, string, error) {
+	subnet := metal.NewUnstructured(metal.SubnetGVK, namespace, name)
+	subnet.SetLabels(clusterLabels(cluster))
+	if err := unstructured.SetNestedMap(subnet.Object, map[string]any{
+		"cidr": cidr,
+		"network": map[string]any{
+			"name": networkName,
+		},
+	}, "spec"); err != nil {
+		return nil, "", err
+	}
+	if err := metalClient.Patch(ctx, subnet, client.Apply, client.ForceOwnership, metal.FieldOwner); err != nil {
+		return nil, "", fmt.Errorf("failed to apply subnet %s: %w", client.ObjectKeyFromObject(subnet), err)
+	}
+
+	if gateway == "" {
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to parse CIDR of subnet %s: %w", name, err)
+		}
+		gateway = prefix.Masked().Addr().Next().String()
+	}
+
+	return &metalv1alpha1.IPAMObjectReference{
+		Name:     subnet.GetName(),
+		APIGroup: metal.IPAMGroup,
+		Kind:     metal.SubnetKind,
+	}, gateway, nil
+}
+
+// loadBalancerPrefixSubnetName returns the name of the IPAM Subnet which reserves the LoadBalancer prefix.
+func loadBalancerPrefixSubnetName(cluster *controller.Cluster) string {
+	return ipamObjectName(cluster, loadBalancerPrefixName)
+}
+
+// applyLoadBalancerPrefix creates or updates the IPAM Subnet with the given name which reserves a prefix of the
+// configured length from the parent subnet. The returned status has no CIDR as long as the prefix has not been
+// reserved by IPAM.
+func applyLoadBalancerPrefix(ctx context.Context, metalClient client.Client, namespace string, cluster *controller.Cluster, name string, prefix *metalv1alpha1.LoadBalancerPrefix) (*metalv1alpha1.LoadBalancerPrefixStatus, error) {
+	parent := metal.NewUnstructured(metal.SubnetGVK, namespace, prefix.ParentSubnet)
+	if err := metalClient.Get(ctx, client.ObjectKeyFromObject(parent), parent); err != nil {
+		return nil, fmt.Errorf("failed to get parent subnet %s: %w", client.ObjectKeyFromObject(parent), err)
+	}
+	networkName, _, _ := unstructured.NestedString(parent.Object, "spec", "network", "name")
+
+	subnet := metal.NewUnstructured(metal.SubnetGVK, namespace, name)
+	subnet.SetLabels(clusterLabels(cluster))
+	if err := unstructured.SetNestedMap(subnet.Object, map[string]any{
+		"prefixBits": int64(prefix.PrefixLength),
+		"parentSubnet": map[string]any{
+			"name": prefix.ParentSubnet,
+		},
+		"network": map[string]any{
+			"name": networkName,
+		},
+	}, "spec"); err != nil {
+		return nil, err
+	}
+	if err := metalClient.Patch(ctx, subnet, client.Apply, client.ForceOwnership, metal.FieldOwner); err != nil {
+		return nil, fmt.Errorf("failed to apply subnet %s: %w", client.ObjectKeyFromObject(subnet), err)
+	}
+
+	if state, _, _ := unstructured.NestedString(subnet.Object, "status", "state"); state == subnetStateFailed {
+		message, _, _ := unstructured.NestedString(subnet.Object, "status", "message")
+		return nil, fmt.Errorf("failed to reserve LoadBalancer prefix in subnet %s: %s", client.ObjectKeyFromObject(subnet), message)
+	}
+	cidr, _, _ := unstructured.NestedString(subnet.Object, "status", "reserved")
+
+	return &metalv1alpha1.LoadBalancerPrefixStatus{
+		CIDR: cidr,
+		SubnetRef: &metalv1alpha1.IPAMObjectReference{
+			Name:     subnet.GetName(),
+			APIGroup: metal.IPAMGroup,
+			Kind:     metal.SubnetKind,
+		},
+	}, nil
+}
+
+// networkID returns the ID reserved for the given IPAM Network, falling back to the requested one.
+func networkID(ipamNetwork *unstructured.Unstructured) string {
+	if id, ok, _ := unstructured.NestedString(ipamNetwork.Object, "status", "reserved"); ok && id != "" {
+		return id
+	}
+	id, _, _ := unstructured.NestedString(ipamNetwork.Object, "spec", "id")
+	return id
+}
+
+// findNetworkByID returns the IPAM Network in the metal namespace which carries the given ID or nil if there is none.
+func findNetworkByID(ctx context.Context, metalClient client.Client, namespace, id string) (*unstructured.Unstructured, error) {
+	list := metal.NewUnstructuredList(metal.NetworkGVK)
+	if err := metalClient.List(ctx, list, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list networks: %w", err)
+	}
+
+	for _, obj := range list.Items {
+		if networkID(&obj) == id {
+			return &obj, nil
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,362 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/gardener/gardener/extensions/pkg/controller"
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/go-logr/logr"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/config"
+	api "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal"
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal/helper"
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/metal"
+)
+
+// tenantServiceAccountName is the name of the ServiceAccount, Role and RoleBinding of a bootstrapped tenant which is
+// used by the extension and the machine-controller-manager.
+const tenantServiceAccountName = metal.ProviderName
+
+// tokenRenewalRatio is the share of their validity after which the tokens of a bootstrapped tenant are renewed.
+const tokenRenewalRatio = 0.8
+
+// tenantIdentity is a ServiceAccount of a bootstrapped tenant together with the permissions of its Role in the
+// namespace of the tenant.
+type tenantIdentity struct {
+	name  string
+	rules []rbacv1.PolicyRule
+}
+
+var (
+	// machineControllerManagerIdentity is used by the extension and the machine-controller-manager, which claim the
+	// servers, create their ignition secrets and IPs and read the IPAM objects provisioned by the extension. Its token
+	// is written into the cloudprovider secret.
+	machineControllerManagerIdentity = tenantIdentity{
+		name: tenantServiceAccountName,
+		rules: []rbacv1.PolicyRule{
+			{
+				APIGroups: []string{metal.IPAMGroup},
+				Resources: []string{"networks", "subnets"},
+				Verbs:     []string{"get", "list", "watch"},
+			},
+			{
+				APIGroups: []string{metal.IPAMGroup},
+				Resources: []string{"ips"},
+				Verbs:     []string{"get", "list", "watch", "create", "update", "patch", "delete"},
+			},
+			{
+				APIGroups: []string{metal.MetalGroup},
+				Resources: []string{"serverclaims"},
+				Verbs:     []string{"get", "list", "watch", "create", "update", "patch", "delete"},
+			},
+			{
+				// The ignition secrets are only accessed by name, hence secrets cannot be listed or watched.
+				APIGroups: []string{corev1.GroupName},
+				Resources: []string{"secrets"},
+				Verbs:     []string{"get", "create", "update", "patch", "delete"},
+			},
+		},
+	}
+	// cloudControllerManagerIdentity is used by the cloud-controller-manager, which only reads the ServerClaims and
+	// IPs of the nodes. Its token is written into the secret of the cloud-controller-manager.
+	cloudControllerManagerIdentity = tenantIdentity{
+		name: metal.CloudControllerManagerName,
+		rules: []rbacv1.PolicyRule{
+			{
+				APIGroups: []string{metal.IPAMGroup},
+				Resources: []string{"ips"},
+				Verbs:     []string{"get", "list", "watch"},
+			},
+			{
+				APIGroups: []string{metal.MetalGroup},
+				Resources: []string{"serverclaims"},
+				Verbs:     []string{"get", "list", "watch"},
+			},
+		},
+	}
+
+	tenantIdentities = []tenantIdentity{machineControllerManagerIdentity, cloudControllerManagerIdentity}
+)
+
+// tenantNamespace returns the name of the namespace bootstrapped for the cluster in the metal cluster.
+func tenantNamespace(cluster *controller.Cluster) string {
+	return cluster.ObjectMeta.Name
+}
+
+// getCloudProviderSecret returns the cloudprovider secret in the namespace of the infrastructure.
+func (a *actuator) getCloudProviderSecret(ctx context.Context, infra *extensionsv1alpha1.Infrastructure) (*corev1.Secret, error) {
+	secret := &corev1.Secret{}
+	if err := a.client.Get(ctx, client.ObjectKey{Namespace: infra.Namespace, Name: v1beta1constants.SecretNameCloudProvider}, secret); err != nil {
+		return nil, fmt.Errorf("failed to get cloudprovider secret: %w", err)
+	}
+	return secret, nil
+}
+
+// isTenantBootstrapped returns whether the cloudprovider secret requests a tenant bootstrapped by the extension.
+func isTenantBootstrapped(secret *corev1.Secret) bool {
+	return string(secret.Data[metal.TenantBootstrapFieldName]) == "true"
+}
+
+// getMetalClientAndNamespace returns a client and the namespace for the objects of the cluster in the metal cluster.
+// The objects of a bootstrapped tenant are managed with the admin credentials of the region.
+func (a *actuator) getMetalClientAndNamespace(ctx context.Context, infra *extensionsv1alpha1.Infrastructure, cluster *controller.Cluster) (client.Client, string, error) {
+	secret, err := a.getCloudProviderSecret(ctx, infra)
+	if err != nil {
+		return nil, "", err
+	}
+	if isTenantBootstrapped(secret) {
+		adminClient, err := a.getTenantAdminClient(ctx, cluster)
+		if err != nil {
+			return nil, "", err
+		}
+		return adminClient, tenantNamespace(cluster), nil
+	}
+
+	metalClient, namespace, err := metal.GetMetalClientAndNamespaceFromCloudProviderSecret(ctx, a.client, infra.Namespace)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get metal client and namespace from cloudprovider secret: %w", err)
+	}
+	return metalClient, namespace, nil
+}
+
+// getTenantAdminClient returns a client with the admin credentials of the shoot's region.
+func (a *actuator) getTenantAdminClient(ctx context.Context, cluster *controller.Cluster) (client.Client, error) {
+	region := cluster.Shoot.Spec.Region
+	idx := slices.IndexFunc(a.tenantBootstrap.Regions, func(r config.TenantBootstrapRegion) bool {
+		return r.Name == region
+	})
+	if idx < 0 {
+		return nil, fmt.Errorf("tenant bootstrap is not configured for region %s", region)
+	}
+
+	adminClient, err := metal.GetMetalClientFromSecretRef(ctx, a.client, &a.tenantBootstrap.Regions[idx].SecretRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get admin client for region %s: %w", region, err)
+	}
+	return adminClient, nil
+}
+
+// ensureTenant creates the namespace of the cluster in the metal cluster together with a ServiceAccount, Role and
+// RoleBinding per tenant identity, if the secret requests a bootstrapped tenant. The tokens of the ServiceAccounts are
+// renewed once they are due.
+func (a *actuator) ensureTenant(ctx context.Context, log logr.Logger, infra *extensionsv1alpha1.Infrastructure, cluster *controller.Cluster) error {
+	secret, err := a.getCloudProviderSecret(ctx, infra)
+	if err != nil {
+		return err
+	}
+	if !isTenantBootstrapped(secret) {
+		return nil
+	}
+
+	adminClient, err := a.getTenantAdminClient(ctx, cluster)
+	if err != nil {
+		return err
+	}
+
+	namespace := tenantNamespace(cluster)
+	log.V(1).Info("Bootstrapping tenant in metal cluster", "namespace", namespace)
+
+	objects := []client.Object{
+		&corev1.Namespace{
+			TypeMeta: metav1.TypeMeta{APIVersion: corev1.SchemeGroupVersion.String(), Kind: "Namespace"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   namespace,
+				Labels: clusterLabels(cluster),
+			},
+		},
+	}
+	for _, identity := range tenantIdentities {
+		objects = append(objects, tenantIdentityObjects(cluster, identity)...)
+	}
+	for _, obj := range objects {
+		if err := adminClient.Patch(ctx, obj, client.Apply, client.ForceOwnership, metal.FieldOwner); err != nil {
+			return fmt.Errorf("failed to apply %T %s: %w", obj, client.ObjectKeyFromObject(obj), err)
+		}
+	}
+
+	if time.Now().Before(tokenRenewalTime(secret)) {
+		return nil
+	}
+	return a.renewTenantTokens(ctx, log, adminClient, infra, cluster, secret)
+}
+
+// tenantIdentityObjects returns the ServiceAccount, Role and RoleBinding of a tenant identity.
+func tenantIdentityObjects(cluster *controller.Cluster, identity tenantIdentity) []client.Object {
+	namespace := tenantNamespace(cluster)
+	return []client.Object{
+		&corev1.ServiceAccount{
+			TypeMeta: metav1.TypeMeta{APIVersion: corev1.SchemeGroupVersion.String(), Kind: "ServiceAccount"},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      identity.name,
+				Labels:    clusterLabels(cluster),
+			},
+		},
+		&rbacv1.Role{
+			TypeMeta: metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "Role"},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      identity.name,
+				Labels:    clusterLabels(cluster),
+			},
+			Rules: identity.rules,
+		},
+		&rbacv1.RoleBinding{
+			TypeMeta: metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "RoleBinding"},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      identity.name,
+				Labels:    clusterLabels(cluster),
+			},
+			RoleRef: rbacv1.RoleRef{
+				APIGroup: rbacv1.GroupName,
+				Kind:     "Role",
+				Name:     identity.name,
+			},
+			Subjects: []rbacv1.Subject{{
+				Kind:      rbacv1.ServiceAccountKind,
+				Namespace: namespace,
+				Name:      identity.name,
+			}},
+		},
+	}
+}
+
+// tokenRenewalTime returns when the tokens of a bootstrapped tenant have to be renewed. The renewal time is kept in the
+// data of the secret, which the cloudprovider webhook preserves when gardenlet updates the secret. Tokens without a
+// recorded renewal time, e.g. because the secret has been recreated, are due immediately.
+func tokenRenewalTime(secret *corev1.Secret) time.Time {
+	renewalTime, err := time.Parse(time.RFC3339, string(secret.Data[metal.TokenRenewalTimeFieldName]))
+	if err != nil {
+		return time.Time{}
+	}
+	return renewalTime
+}
+
+// renewTenantTokens mints fresh tokens for the tenant identities. The token of the machine-controller-manager is
+// written into the cloudprovider secret, the one of the cloud-controller-manager into a secret of its own. Both
+// secrets record when the tokens have to be renewed next.
+func (a *actuator) renewTenantTokens(ctx context.Context, log logr.Logger, adminClient client.Client, infra *extensionsv1alpha1.Infrastructure, cluster *controller.Cluster, secret *corev1.Secret) error {
+	namespace := tenantNamespace(cluster)
+	log.Info("Renewing tokens of tenant in metal cluster", "namespace", namespace)
+
+	now := time.Now()
+	mcmToken, err := a.requestToken(ctx, adminClient, namespace, machineControllerManagerIdentity.name)
+	if err != nil {
+		return err
+	}
+	ccmToken, err := a.requestToken(ctx, adminClient, namespace, cloudControllerManagerIdentity.name)
+	if err != nil {
+		return err
+	}
+	// The API server may shorten the requested validity, hence the renewal is based on the actual expiration.
+	validity := min(mcmToken.Status.ExpirationTimestamp.Sub(now), ccmToken.Status.ExpirationTimestamp.Sub(now))
+	renewalTime := now.Add(time.Duration(float64(validity) * tokenRenewalRatio)).UTC().Format(time.RFC3339)
+
+	cloudProfileConfig, err := helper.CloudProfileConfigFromCluster(cluster)
+	if err != nil {
+		return err
+	}
+	region := cluster.Shoot.Spec.Region
+	var regionConfig *api.RegionConfig
+	if cloudProfileConfig != nil {
+		if idx := slices.IndexFunc(cloudProfileConfig.RegionConfigs, func(r api.RegionConfig) bool { return r.Name == region }); idx >= 0 {
+			regionConfig = &cloudProfileConfig.RegionConfigs[idx]
+		}
+	}
+	if regionConfig == nil {
+		return fmt.Errorf("failed to find region %s in cloudprofile", region)
+	}
+	ccmUsername := serviceAccountUsername(namespace, cloudControllerManagerIdentity.name)
+	kubeconfig, err := metal.NewKubeconfig(region, regionConfig.Server, regionConfig.CertificateAuthorityData, namespace, ccmUsername, ccmToken.Status.Token)
+	if err != nil {
+		return err
+	}
+
+	ccmSecret := &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{APIVersion: corev1.SchemeGroupVersion.String(), Kind: "Secret"},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: infra.Namespace,
+			Name:      metal.CloudControllerManagerSecretName,
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			metal.NamespaceFieldName:        []byte(namespace),
+			metal.UsernameFieldName:         []byte(ccmUsername),
+			metal.TokenFieldName:            []byte(ccmToken.Status.Token),
+			metal.TokenRenewalTimeFieldName: []byte(renewalTime),
+			metal.KubeConfigFieldName:       kubeconfig,
+		},
+	}
+	if err := a.client.Patch(ctx, ccmSecret, client.Apply, client.ForceOwnership, metal.FieldOwner); err != nil {
+		return fmt.Errorf("failed to apply secret %s: %w", client.ObjectKeyFromObject(ccmSecret), err)
+	}
+
+	// The renewal time of the cloudprovider secret is recorded last, hence a failed renewal is retried.
+	patch := client.MergeFrom(secret.DeepCopy())
+	secret.Data[metal.TokenRenewalTimeFieldName] = []byte(renewalTime)
+	secret.Data[metal.NamespaceFieldName] = []byte(namespace)
+	secret.Data[metal.UsernameFieldName] = []byte(serviceAccountUsername(namespace, machineControllerManagerIdentity.name))
+	secret.Data[metal.TokenFieldName] = []byte(mcmToken.Status.Token)
+	if err := a.client.Patch(ctx, secret, patch); err != nil {
+		return fmt.Errorf("failed to patch cloudprovider secret: %w", err)
+	}
+
+	return nil
+}
+
+// requestToken mints a token for the ServiceAccount with the configured validity.
+func (a *actuator) requestToken(ctx context.Context, adminClient client.Client, namespace, name string) (*authenticationv1.TokenRequest, error) {
+	serviceAccount := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+	tokenRequest := &authenticationv1.TokenRequest{}
+	if a.tenantBootstrap.TokenExpiration != nil {
+		tokenRequest.Spec.ExpirationSeconds = ptr.To(int64(a.tenantBootstrap.TokenExpiration.Seconds()))
+	}
+	if err := adminClient.SubResource("token").Create(ctx, serviceAccount, tokenRequest); err != nil {
+		return nil, fmt.Errorf("failed to request token for service account %s: %w", client.ObjectKeyFromObject(serviceAccount), err)
+	}
+	return tokenRequest, nil
+}
+
+// serviceAccountUsername returns the username of a ServiceAccount.
+func serviceAccountUsername(namespace, name string) string {
+	return fmt.Sprintf("system:serviceaccount:%s:%s", namespace, name)
+}
+
+// deleteTenant deletes the namespace of the cluster in the metal cluster, if the cloudprovider secret requests a
+// bootstrapped tenant. The namespaced RBAC objects and the ServiceAccount are removed together with the namespace.
+func (a *actuator) deleteTenant(ctx context.Context, log logr.Logger, infra *extensionsv1alpha1.Infrastructure, cluster *controller.Cluster) error {
+	secret, err := a.getCloudProviderSecret(ctx, infra)
+	if err != nil {
+		return err
+	}
+	if !isTenantBootstrapped(secret) {
+		return nil
+	}
+
+	adminClient, err := a.getTenantAdminClient(ctx, cluster)
+	if err != nil {
+		return err
+	}
+
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: tenantNamespace(cluster)}}
+	log.V(1).Info("Deleting tenant in metal cluster", "namespace", namespace.Name)
+	if err := adminClient.Delete(ctx, namespace); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete namespace %s: %w", namespace.Name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,180 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package infrastructure
+
+import (
+	"encoding/json"
+	"time"
+
+	extensionscontroller "github.com/gardener/gardener/extensions/pkg/controller"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	. "sigs.k8s.io/controller-runtime/pkg/envtest/komega"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/config"
+	metalv1alpha1 "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal/v1alpha1"
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/metal"
+)
+
+var _ = Describe("Tenant Bootstrap", func() {
+	var (
+		log     logr.Logger
+		infra   *extensionsv1alpha1.Infrastructure
+		cluster *extensionscontroller.Cluster
+		act     *actuator
+	)
+
+	BeforeEach(func(ctx SpecContext) {
+		log = logr.Discard()
+
+		infrastructureConfigRaw, err := json.Marshal(metalv1alpha1.InfrastructureConfig{
+			Networks: []metalv1alpha1.Networks{
+				{Name: "worker-network-1", CIDR: "10.10.10.0/24"},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		infra = &extensionsv1alpha1.Infrastructure{}
+		infra.Name = "infra-with-tenant"
+		infra.Namespace = metav1.NamespaceDefault
+		infra.Spec.ProviderConfig = &runtime.RawExtension{Raw: infrastructureConfigRaw}
+		Expect(k8sClient.Create(ctx, infra)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, infra)
+
+		SetupCloudProviderSecret(ctx, infra.Namespace)
+		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: infra.Namespace, Name: v1beta1constants.SecretNameCloudProvider}}
+		Eventually(Update(secret, func() {
+			secret.Data = map[string][]byte{metal.TenantBootstrapFieldName: []byte("true")}
+		})).Should(Succeed())
+
+		adminSecret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: metav1.NamespaceDefault,
+				Name:      "metal-admin-foo",
+			},
+			Data: map[string][]byte{"kubeconfig": kubeconfig},
+		}
+		Expect(k8sClient.Create(ctx, adminSecret)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, adminSecret)
+
+		cloudProfileConfigRaw, err := json.Marshal(metalv1alpha1.CloudProfileConfig{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: metalv1alpha1.SchemeGroupVersion.String(),
+				Kind:       "CloudProfileConfig",
+			},
+			RegionConfigs: []metalv1alpha1.RegionConfig{{Name: "foo", Server: "https://metal.foo.example.com"}},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		cluster = &extensionscontroller.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "shoot--foo--tenant"},
+			CloudProfile: &gardencorev1beta1.CloudProfile{
+				Spec: gardencorev1beta1.CloudProfileSpec{
+					ProviderConfig: &runtime.RawExtension{Raw: cloudProfileConfigRaw},
+				},
+			},
+			Shoot: &gardencorev1beta1.Shoot{
+				Spec: gardencorev1beta1.ShootSpec{Region: "foo"},
+			},
+		}
+
+		act = &actuator{
+			client: k8sClient,
+			tenantBootstrap: config.TenantBootstrap{
+				Regions: []config.TenantBootstrapRegion{{
+					Name:      "foo",
+					SecretRef: corev1.SecretReference{Namespace: adminSecret.Namespace, Name: adminSecret.Name},
+				}},
+				TokenExpiration: &metav1.Duration{Duration: time.Hour},
+			},
+		}
+	})
+
+	It("should bootstrap the tenant and provision the networks in its namespace", func(ctx SpecContext) {
+		Expect(act.Reconcile(ctx, log, infra, cluster)).To(Succeed())
+
+		namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "shoot--foo--tenant"}}
+		Eventually(Object(namespace)).Should(HaveField("Labels", HaveKeyWithValue(metal.ClusterNameLabel, "shoot--foo--tenant")))
+
+		for _, identity := range tenantIdentities {
+			role := &rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Namespace: namespace.Name, Name: identity.name}}
+			Eventually(Object(role)).Should(HaveField("Rules", Equal(identity.rules)))
+			roleBinding := &rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Namespace: namespace.Name, Name: identity.name}}
+			Eventually(Get(roleBinding)).Should(Succeed())
+			serviceAccount := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Namespace: namespace.Name, Name: identity.name}}
+			Eventually(Get(serviceAccount)).Should(Succeed())
+		}
+
+		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: infra.Namespace, Name: v1beta1constants.SecretNameCloudProvider}}
+		Eventually(Object(secret)).Should(HaveField("Data", SatisfyAll(
+			HaveKeyWithValue(metal.NamespaceFieldName, []byte("shoot--foo--tenant")),
+			HaveKeyWithValue(metal.UsernameFieldName, []byte("system:serviceaccount:shoot--foo--tenant:"+tenantServiceAccountName)),
+			HaveKeyWithValue(metal.TokenFieldName, Not(BeEmpty())),
+		)))
+		Expect(secret.Data).To(HaveKey(metal.TokenRenewalTimeFieldName))
+
+		ccmSecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: infra.Namespace, Name: metal.CloudControllerManagerSecretName}}
+		DeferCleanup(k8sClient.Delete, ccmSecret)
+		Eventually(Object(ccmSecret)).Should(HaveField("Data", SatisfyAll(
+			HaveKeyWithValue(metal.UsernameFieldName, []byte("system:serviceaccount:shoot--foo--tenant:"+metal.CloudControllerManagerName)),
+			HaveKeyWithValue(metal.TokenFieldName, Not(Equal(secret.Data[metal.TokenFieldName]))),
+			HaveKey(metal.KubeConfigFieldName),
+		)))
+
+		subnet := metal.NewUnstructured(metal.SubnetGVK, namespace.Name, "shoot--foo--tenant-worker-network-1")
+		Eventually(Get(subnet)).Should(Succeed())
+	})
+
+	It("should delete the namespace of the tenant", func(ctx SpecContext) {
+		// Namespaces are never finalized in the test environment, hence every test needs its own tenant.
+		cluster.ObjectMeta.Name = "shoot--foo--tenant-delete"
+
+		Expect(act.Reconcile(ctx, log, infra, cluster)).To(Succeed())
+		Expect(act.Delete(ctx, log, infra, cluster)).To(Succeed())
+
+		namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "shoot--foo--tenant-delete"}}
+		Eventually(func() bool {
+			err := k8sClient.Get(ctx, client.ObjectKeyFromObject(namespace), namespace)
+			return apierrors.IsNotFound(err) || namespace.DeletionTimestamp != nil
+		}).Should(BeTrue())
+	})
+
+	It("should only renew the tokens once they are due", func(ctx SpecContext) {
+		cluster.ObjectMeta.Name = "shoot--foo--tenant-renewal"
+		ccmSecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: infra.Namespace, Name: metal.CloudControllerManagerSecretName}}
+		DeferCleanup(func(ctx SpecContext) {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, ccmSecret))).To(Succeed())
+		})
+
+		Expect(act.ensureTenant(ctx, log, infra, cluster)).To(Succeed())
+		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: infra.Namespace, Name: v1beta1constants.SecretNameCloudProvider}}
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(secret), secret)).To(Succeed())
+		token := secret.Data[metal.TokenFieldName]
+
+		Expect(act.ensureTenant(ctx, log, infra, cluster)).To(Succeed())
+		Consistently(Object(secret)).Should(HaveField("Data", HaveKeyWithValue(metal.TokenFieldName, token)))
+
+		reconciler := &tokenRotationReconciler{actuator: act}
+		result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(infra)})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeNumerically("~", 48*time.Minute, time.Minute))
+
+		Eventually(Update(secret, func() {
+			secret.Data[metal.TokenRenewalTimeFieldName] = []byte(time.Now().Add(-time.Minute).UTC().Format(time.RFC3339))
+		})).Should(Succeed())
+		Expect(act.ensureTenant(ctx, log, infra, cluster)).To(Succeed())
+		Eventually(Object(secret)).Should(HaveField("Data", HaveKeyWithValue(metal.TokenFieldName, Not(Equal(token)))))
+	})
+})
@@ -0,0 +1,91 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	extensionscontroller "github.com/gardener/gardener/extensions/pkg/controller"
+	extensionspredicate "github.com/gardener/gardener/extensions/pkg/predicate"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/metal"
+)
+
+// tokenRotationControllerName is the name of the controller which renews the tokens of bootstrapped tenants.
+const tokenRotationControllerName = metal.Type + "-infrastructure-token-rotation"
+
+// tokenBootstrapRequeueInterval is the interval in which an infrastructure is requeued while the tokens of its tenant
+// have not been minted by the infrastructure actuator yet.
+const tokenBootstrapRequeueInterval = time.Minute
+
+// tokenRotationReconciler renews the tokens of bootstrapped tenants before they expire. Infrastructures are only
+// reconciled together with their Shoot, which may happen less often than the tokens expire.
+type tokenRotationReconciler struct {
+	actuator *actuator
+}
+
+// addTokenRotationController adds the controller which renews the tokens of bootstrapped tenants to the manager.
+func addTokenRotationController(mgr manager.Manager, opts AddOptions) error {
+	return builder.ControllerManagedBy(mgr).
+		Named(tokenRotationControllerName).
+		For(&extensionsv1alpha1.Infrastructure{}, builder.WithPredicates(
+			extensionspredicate.HasType(metal.Type),
+			extensionspredicate.HasClass(opts.ExtensionClass),
+		)).
+		WithOptions(opts.Controller).
+		Complete(&tokenRotationReconciler{actuator: NewActuator(mgr, opts.TenantBootstrap).(*actuator)})
+}
+
+// Reconcile renews the tokens of the bootstrapped tenant of an infrastructure once they are due and requeues the
+// infrastructure for the next renewal. The infrastructure is requeued at the renewal time recorded in the
+// cloudprovider secret, as neither the secret nor the infrastructure necessarily change until then.
+func (r *tokenRotationReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	log := logf.FromContext(ctx)
+
+	infra := &extensionsv1alpha1.Infrastructure{}
+	if err := r.actuator.client.Get(ctx, req.NamespacedName, infra); err != nil {
+		return reconcile.Result{}, client.IgnoreNotFound(err)
+	}
+	if infra.DeletionTimestamp != nil {
+		return reconcile.Result{}, nil
+	}
+
+	secret, err := r.actuator.getCloudProviderSecret(ctx, infra)
+	if err != nil {
+		return reconcile.Result{}, client.IgnoreNotFound(err)
+	}
+	if !isTenantBootstrapped(secret) {
+		return reconcile.Result{}, nil
+	}
+	// The tenant is bootstrapped by the infrastructure actuator, only its tokens are renewed here.
+	if len(secret.Data[metal.TokenFieldName]) == 0 {
+		return reconcile.Result{RequeueAfter: tokenBootstrapRequeueInterval}, nil
+	}
+
+	if wait := time.Until(tokenRenewalTime(secret)); wait > 0 {
+		return reconcile.Result{RequeueAfter: wait}, nil
+	}
+
+	cluster, err := extensionscontroller.GetCluster(ctx, r.actuator.client, infra.Namespace)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to get cluster: %w", err)
+	}
+	adminClient, err := r.actuator.getTenantAdminClient(ctx, cluster)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if err := r.actuator.renewTenantTokens(ctx, log, adminClient, infra, cluster, secret); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	return reconcile.Result{RequeueAfter: time.Until(tokenRenewalTime(secret))}, nil
+}
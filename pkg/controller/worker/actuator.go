@@ -5,6 +5,8 @@ package worker
 
 import (
 	"context"
+	"fmt"
+	"slices"
 
 	extensionscontroller "github.com/gardener/gardener/extensions/pkg/controller"
 	"github.com/gardener/gardener/extensions/pkg/controller/worker"
@@ -18,8 +20,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/cluster"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/config"
 	api "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal"
 	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal/helper"
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/metal"
 )
 
 type delegateFactory struct {
@@ -28,6 +32,9 @@ type delegateFactory struct {
 	decoder      runtime.Decoder
 	restConfig   *rest.Config
 	scheme       *runtime.Scheme
+
+	tenantBootstrap    config.TenantBootstrap
+	serverSanitization config.ServerSanitization
 }
 
 type actuator struct {
@@ -36,13 +43,15 @@ type actuator struct {
 }
 
 // NewActuator creates a new Actuator that updates the status of the handled WorkerPoolConfigs.
-func NewActuator(mgr manager.Manager, gardenCluster cluster.Cluster) worker.Actuator {
+func NewActuator(mgr manager.Manager, gardenCluster cluster.Cluster, tenantBootstrap config.TenantBootstrap, serverSanitization config.ServerSanitization) worker.Actuator {
 	workerDelegate := &delegateFactory{
-		gardenReader: gardenCluster.GetAPIReader(),
-		seedClient:   mgr.GetClient(),
-		decoder:      serializer.NewCodecFactory(mgr.GetScheme(), serializer.EnableStrict).UniversalDecoder(),
-		restConfig:   mgr.GetConfig(),
-		scheme:       mgr.GetScheme(),
+		gardenReader:       gardenCluster.GetAPIReader(),
+		seedClient:         mgr.GetClient(),
+		decoder:            serializer.NewCodecFactory(mgr.GetScheme(), serializer.EnableStrict).UniversalDecoder(),
+		restConfig:         mgr.GetConfig(),
+		scheme:             mgr.GetScheme(),
+		tenantBootstrap:    tenantBootstrap,
+		serverSanitization: serverSanitization,
 	}
 
 	return &actuator{
@@ -69,6 +78,8 @@ func (d *delegateFactory) WorkerDelegate(_ context.Context, worker *extensionsv1
 		serverVersion.GitVersion,
 		worker,
 		cluster,
+		d.tenantBootstrap,
+		d.serverSanitization,
 	)
 }
 
@@ -81,6 +92,13 @@ type workerDelegate struct {
 	cloudProfileConfig *api.CloudProfileConfig
 	cluster            *extensionscontroller.Cluster
 	worker             *extensionsv1alpha1.Worker
+
+	metalClient    client.Client
+	metalNamespace string
+
+	tenantBootstrap    config.TenantBootstrap
+	serverSanitization config.ServerSanitization
+	serverReader       client.Reader
 }
 
 // NewWorkerDelegate creates a new context for a worker reconciliation.
@@ -91,6 +109,8 @@ func NewWorkerDelegate(
 	serverVersion string,
 	worker *extensionsv1alpha1.Worker,
 	cluster *extensionscontroller.Cluster,
+	tenantBootstrap config.TenantBootstrap,
+	serverSanitization config.ServerSanitization,
 ) (
 	genericactuator.WorkerDelegate,
 	error,
@@ -108,5 +128,48 @@ func NewWorkerDelegate(
 		cloudProfileConfig: config,
 		cluster:            cluster,
 		worker:             worker,
+		tenantBootstrap:    tenantBootstrap,
+		serverSanitization: serverSanitization,
 	}, nil
 }
+
+// getMetalClient returns a client for the metal cluster and the namespace of the worker. The client is created from
+// the credentials of the worker on first use.
+func (w *workerDelegate) getMetalClient(ctx context.Context) (client.Client, string, error) {
+	if w.metalClient == nil {
+		metalClient, namespace, err := metal.GetMetalClientAndNamespaceFromSecretRef(ctx, w.client, &w.worker.Spec.SecretRef)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to get metal client: %w", err)
+		}
+		w.metalClient, w.metalNamespace = metalClient, namespace
+	}
+	return w.metalClient, w.metalNamespace, nil
+}
+
+// getServerReader returns a reader for the Servers of the metal cluster. Servers are cluster-scoped, the credentials of
+// the worker usually only grant access to its namespace. Hence, the admin credentials of the region are used if they
+// are configured, otherwise the credentials of the worker.
+func (w *workerDelegate) getServerReader(ctx context.Context) (client.Reader, error) {
+	if w.serverReader != nil {
+		return w.serverReader, nil
+	}
+
+	idx := slices.IndexFunc(w.tenantBootstrap.Regions, func(r config.TenantBootstrapRegion) bool {
+		return r.Name == w.worker.Spec.Region
+	})
+	if idx < 0 {
+		metalClient, _, err := w.getMetalClient(ctx)
+		if err != nil {
+			return nil, err
+		}
+		w.serverReader = metalClient
+		return w.serverReader, nil
+	}
+
+	adminClient, err := metal.GetMetalClientFromSecretRef(ctx, w.client, &w.tenantBootstrap.Regions[idx].SecretRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get admin client for region %s: %w", w.worker.Spec.Region, err)
+	}
+	w.serverReader = adminClient
+	return w.serverReader, nil
+}
@@ -7,14 +7,24 @@ import (
 	"context"
 
 	"github.com/gardener/gardener/extensions/pkg/controller/worker"
+	extensionspredicate "github.com/gardener/gardener/extensions/pkg/predicate"
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/gardener/gardener/pkg/controllerutils/mapper"
 	machinev1alpha1 "github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
 	apiextensionsscheme "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/scheme"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/cluster"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/config"
 	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/metal"
 )
 
@@ -36,6 +46,10 @@ type AddOptions struct {
 	RecoverPanic              *bool
 	// ExtensionClass defines the extension class this extension is responsible for.
 	ExtensionClass extensionsv1alpha1.ExtensionClass
+	// TenantBootstrap contains the admin credentials of the regions, which are used to read the Servers.
+	TenantBootstrap config.TenantBootstrap
+	// ServerSanitization is the configuration for verifying that released Servers have been sanitized.
+	ServerSanitization config.ServerSanitization
 }
 
 // AddToManagerWithOptions adds a controller with the given Options to the given manager.
@@ -49,13 +63,41 @@ func AddToManagerWithOptions(ctx context.Context, mgr manager.Manager, opts AddO
 		return err
 	}
 
-	return worker.Add(ctx, mgr, worker.AddArgs{
-		Actuator:          NewActuator(mgr, opts.GardenCluster),
-		ControllerOptions: opts.Controller,
-		Predicates:        worker.DefaultPredicates(ctx, mgr, opts.IgnoreOperationAnnotation),
-		Type:              metal.Type,
-		ExtensionClass:    opts.ExtensionClass,
-	})
+	// The controller is set up like worker.Add does, with an additional watch for the ignition secrets referenced
+	// by the WorkerConfigs.
+	predicates := extensionspredicate.AddTypePredicate(worker.DefaultPredicates(ctx, mgr, opts.IgnoreOperationAnnotation), metal.Type)
+	predicates = append(predicates, extensionspredicate.HasClass(opts.ExtensionClass))
+
+	opts.Controller.Reconciler = worker.NewReconciler(mgr, NewActuator(mgr, opts.GardenCluster, opts.TenantBootstrap, opts.ServerSanitization))
+	ctrl, err := controller.New(worker.ControllerName, mgr, opts.Controller)
+	if err != nil {
+		return err
+	}
+
+	if opts.IgnoreOperationAnnotation {
+		if err := ctrl.Watch(source.Kind[client.Object](
+			mgr.GetCache(),
+			&extensionsv1alpha1.Cluster{},
+			mapper.EnqueueRequestsFrom(ctx, mgr.GetCache(), worker.ClusterToWorkerMapper(mgr, predicates), mapper.UpdateWithNew, ctrl.GetLogger()),
+		)); err != nil {
+			return err
+		}
+	}
+
+	if err := ctrl.Watch(source.Kind[client.Object](mgr.GetCache(), &extensionsv1alpha1.Worker{}, &handler.EnqueueRequestForObject{}, predicates...)); err != nil {
+		return err
+	}
+
+	// Only the metadata of secrets is watched, a changed resource version is sufficient to notice content changes.
+	secret := &metav1.PartialObjectMetadata{}
+	secret.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Secret"))
+	decoder := serializer.NewCodecFactory(scheme, serializer.EnableStrict).UniversalDecoder()
+	return ctrl.Watch(source.Kind(
+		mgr.GetCache(),
+		secret,
+		handler.TypedEnqueueRequestsFromMapFunc(mapIgnitionSecretToWorkers(ctrl.GetLogger(), mgr.GetClient(), decoder)),
+		predicate.TypedResourceVersionChangedPredicate[*metav1.PartialObjectMetadata]{},
+	))
 }
 
 // AddToManager adds a controller with the default Options.
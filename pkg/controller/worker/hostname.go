@@ -0,0 +1,60 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package worker
+
+import (
+	"fmt"
+
+	"k8s.io/utils/ptr"
+
+	metalv1alpha1 "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal/v1alpha1"
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/ignition"
+)
+
+const (
+	// kubeletUnitName is the name of the systemd unit of the kubelet.
+	kubeletUnitName = "kubelet.service"
+	// hostnameDropinName is the name of the drop-in of the kubelet unit setting the hostname of a machine.
+	hostnameDropinName = "10-hostname.conf"
+	// hostnameDropinContents sets the hostname to the fully qualified name the machine resolves to via reverse DNS
+	// before the kubelet starts.
+	hostnameDropinContents = `[Service]
+ExecStartPre=/bin/sh -c 'hostnamectl set-hostname $(hostname -f)'
+`
+)
+
+// hostnameIgnition returns the Ignition config of the drop-in of the kubelet unit setting the hostname of machines
+// without a hostname policy.
+func hostnameIgnition() *ignition.Spec {
+	return &ignition.Spec{
+		Systemd: &ignition.Systemd{
+			Units: []ignition.Unit{{
+				Name: kubeletUnitName,
+				Dropins: []ignition.Dropin{{
+					Name:     hostnameDropinName,
+					Contents: ptr.To(hostnameDropinContents),
+				}},
+			}},
+		},
+	}
+}
+
+// machineIgnition returns the Ignition config of the machines of a worker pool. Without a hostname policy, the drop-in
+// of the kubelet unit setting the hostname is added to the merged Ignition config, entries of the merged config take
+// precedence. The drop-in is not part of the worker pool hash, as the hash already covers the hostname policy.
+func machineIgnition(mergedIgnition string, hostnamePolicy *metalv1alpha1.HostnamePolicy) (string, error) {
+	if hostnamePolicy != nil {
+		return mergedIgnition, nil
+	}
+
+	hostnameConfig, err := hostnameIgnition().Config()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate hostname ignition: %w", err)
+	}
+	mergedConfig, err := ignition.Parse([]byte(mergedIgnition))
+	if err != nil {
+		return "", fmt.Errorf("failed to parse merged ignition: %w", err)
+	}
+	return ignition.Marshal(ignition.Merge(hostnameConfig, mergedConfig))
+}
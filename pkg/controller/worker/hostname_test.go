@@ -0,0 +1,55 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package worker
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	apiv1alpha1 "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal/v1alpha1"
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/ignition"
+)
+
+var _ = Describe("Hostname", func() {
+	Describe("#machineIgnition", func() {
+		It("should keep the merged ignition if a hostname policy is configured", func() {
+			Expect(machineIgnition("a: b\n", &apiv1alpha1.HostnamePolicy{Source: apiv1alpha1.HostnameSourceServerName})).To(Equal("a: b\n"))
+		})
+
+		It("should add the hostname drop-in of the kubelet unit without a hostname policy", func() {
+			data, err := machineIgnition("", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			cfg, err := ignition.Parse([]byte(data))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg).To(HaveKeyWithValue("systemd", map[string]any{"units": []any{map[string]any{
+				"name": "kubelet.service",
+				"dropins": []any{map[string]any{
+					"name":     "10-hostname.conf",
+					"contents": "[Service]\nExecStartPre=/bin/sh -c 'hostnamectl set-hostname $(hostname -f)'\n",
+				}},
+			}}}))
+		})
+
+		It("should let the merged ignition override the hostname drop-in", func() {
+			data, err := machineIgnition(`
+systemd:
+  units:
+  - name: kubelet.service
+    dropins:
+    - name: 10-hostname.conf
+      contents: |
+        [Service]
+`, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			cfg, err := ignition.Parse([]byte(data))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg).To(HaveKeyWithValue("systemd", map[string]any{"units": []any{map[string]any{
+				"name":    "kubelet.service",
+				"dropins": []any{map[string]any{"name": "10-hostname.conf", "contents": "[Service]\n"}},
+			}}}))
+		})
+	})
+})
@@ -0,0 +1,92 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package worker
+
+import (
+	"context"
+	"fmt"
+
+	extensionscontroller "github.com/gardener/gardener/extensions/pkg/controller"
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	v1beta1helper "github.com/gardener/gardener/pkg/apis/core/v1beta1/helper"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	metalv1alpha1 "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal/v1alpha1"
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/metal"
+)
+
+// decodeWorkerConfig decodes the WorkerConfig of the given worker pool. A pool without provider config results in an
+// empty WorkerConfig.
+func decodeWorkerConfig(decoder runtime.Decoder, pool extensionsv1alpha1.WorkerPool) (*metalv1alpha1.WorkerConfig, error) {
+	workerConfig := &metalv1alpha1.WorkerConfig{}
+	if pool.ProviderConfig != nil && pool.ProviderConfig.Raw != nil {
+		if _, _, err := decoder.Decode(pool.ProviderConfig.Raw, nil, workerConfig); err != nil {
+			return nil, fmt.Errorf("could not decode provider config: %+v", err)
+		}
+	}
+	return workerConfig, nil
+}
+
+// ignitionSecretName returns the name of the ignition secret referenced by the WorkerConfig in the namespace of the
+// Worker. If the reference names a resource of the Shoot's spec.resources, the copy of the referenced secret made by
+// gardenlet is used.
+func ignitionSecretName(cluster *extensionscontroller.Cluster, workerConfig *metalv1alpha1.WorkerConfig) string {
+	if workerConfig.ExtraIgnition == nil || workerConfig.ExtraIgnition.SecretRef == nil {
+		return ""
+	}
+
+	name := workerConfig.ExtraIgnition.SecretRef.Name
+	if cluster != nil && cluster.Shoot != nil {
+		if resource := v1beta1helper.GetResourceByName(cluster.Shoot.Spec.Resources, name); resource != nil && resource.ResourceRef.Kind == "Secret" {
+			return v1beta1constants.ReferencedResourcesPrefix + resource.ResourceRef.Name
+		}
+	}
+	return name
+}
+
+// mapIgnitionSecretToWorkers returns a mapper which enqueues the metal Workers in the namespace of a secret that
+// reference the secret as ignition secret.
+func mapIgnitionSecretToWorkers(log logr.Logger, c client.Client, decoder runtime.Decoder) handler.TypedMapFunc[*metav1.PartialObjectMetadata, reconcile.Request] {
+	return func(ctx context.Context, secret *metav1.PartialObjectMetadata) []reconcile.Request {
+		workerList := &extensionsv1alpha1.WorkerList{}
+		if err := c.List(ctx, workerList, client.InNamespace(secret.Namespace)); err != nil {
+			log.Error(err, "Failed to list workers", "namespace", secret.Namespace)
+			return nil
+		}
+		if len(workerList.Items) == 0 {
+			return nil
+		}
+
+		cluster, err := extensionscontroller.GetCluster(ctx, c, secret.Namespace)
+		if err != nil {
+			log.Error(err, "Failed to get cluster", "namespace", secret.Namespace)
+			return nil
+		}
+
+		var requests []reconcile.Request
+		for _, worker := range workerList.Items {
+			if worker.Spec.Type != metal.Type {
+				continue
+			}
+			for _, pool := range worker.Spec.Pools {
+				workerConfig, err := decodeWorkerConfig(decoder, pool)
+				if err != nil {
+					log.Error(err, "Failed to decode worker config", "worker", client.ObjectKeyFromObject(&worker), "pool", pool.Name)
+					continue
+				}
+				if ignitionSecretName(cluster, workerConfig) == secret.Name {
+					requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&worker)})
+					break
+				}
+			}
+		}
+		return requests
+	}
+}
@@ -0,0 +1,113 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package worker
+
+import (
+	"encoding/json"
+
+	extensionscontroller "github.com/gardener/gardener/extensions/pkg/controller"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiv1alpha1 "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal/v1alpha1"
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/metal"
+)
+
+var _ = Describe("Ignition secret", func() {
+	const namespace = "shoot--foo--bar"
+
+	var resources []gardencorev1beta1.NamedResourceReference
+
+	BeforeEach(func() {
+		resources = []gardencorev1beta1.NamedResourceReference{{
+			Name: "my-ignition",
+			ResourceRef: autoscalingv1.CrossVersionObjectReference{
+				APIVersion: "v1",
+				Kind:       "Secret",
+				Name:       "ignition-secret",
+			},
+		}}
+	})
+
+	workerConfigFor := func(secretName string) *apiv1alpha1.WorkerConfig {
+		return &apiv1alpha1.WorkerConfig{
+			ExtraIgnition: &apiv1alpha1.IgnitionConfig{
+				SecretRef: &corev1.LocalObjectReference{Name: secretName},
+			},
+		}
+	}
+
+	Describe("#ignitionSecretName", func() {
+		It("should return the referenced secret", func() {
+			cluster := &extensionscontroller.Cluster{Shoot: &gardencorev1beta1.Shoot{}}
+			Expect(ignitionSecretName(cluster, workerConfigFor("my-secret"))).To(Equal("my-secret"))
+		})
+
+		It("should return the copy of a secret referenced in the shoot resources", func() {
+			cluster := &extensionscontroller.Cluster{Shoot: &gardencorev1beta1.Shoot{Spec: gardencorev1beta1.ShootSpec{Resources: resources}}}
+			Expect(ignitionSecretName(cluster, workerConfigFor("my-ignition"))).To(Equal("ref-ignition-secret"))
+		})
+
+		It("should return an empty name without ignition secret", func() {
+			Expect(ignitionSecretName(nil, &apiv1alpha1.WorkerConfig{})).To(BeEmpty())
+		})
+	})
+
+	Describe("#mapIgnitionSecretToWorkers", func() {
+		It("should enqueue the workers referencing the secret", func(ctx SpecContext) {
+			shoot := &gardencorev1beta1.Shoot{
+				TypeMeta: metav1.TypeMeta{APIVersion: gardencorev1beta1.SchemeGroupVersion.String(), Kind: "Shoot"},
+				Spec:     gardencorev1beta1.ShootSpec{Resources: resources},
+			}
+			shootJSON, err := json.Marshal(shoot)
+			Expect(err).NotTo(HaveOccurred())
+			cluster := &extensionsv1alpha1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: namespace},
+				Spec: extensionsv1alpha1.ClusterSpec{
+					Shoot: runtime.RawExtension{Raw: shootJSON},
+				},
+			}
+
+			newWorker := func(name, workerType, secretName string) *extensionsv1alpha1.Worker {
+				workerConfigJSON, err := json.Marshal(workerConfigFor(secretName))
+				Expect(err).NotTo(HaveOccurred())
+				return &extensionsv1alpha1.Worker{
+					ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+					Spec: extensionsv1alpha1.WorkerSpec{
+						DefaultSpec: extensionsv1alpha1.DefaultSpec{Type: workerType},
+						Pools: []extensionsv1alpha1.WorkerPool{
+							{Name: "pool", ProviderConfig: &runtime.RawExtension{Raw: workerConfigJSON}},
+						},
+					},
+				}
+			}
+
+			c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(
+				cluster,
+				newWorker("referencing", metal.Type, "my-ignition"),
+				newWorker("other-secret", metal.Type, "other"),
+				newWorker("other-type", "other", "my-ignition"),
+			).Build()
+			decoder := serializer.NewCodecFactory(scheme.Scheme, serializer.EnableStrict).UniversalDecoder()
+			mapper := mapIgnitionSecretToWorkers(GinkgoLogr, c, decoder)
+
+			secret := &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Name: "ref-ignition-secret", Namespace: namespace}}
+			Expect(mapper(ctx, secret)).To(ConsistOf(reconcile.Request{NamespacedName: client.ObjectKey{Namespace: namespace, Name: "referencing"}}))
+
+			secret.Name = "my-ignition"
+			Expect(mapper(ctx, secret)).To(BeEmpty())
+		})
+	})
+})
@@ -20,21 +20,30 @@ func (w *workerDelegate) CleanupMachineDependencies(ctx context.Context) error {
 }
 
 // PreReconcileHook implements genericactuator.WorkerDelegate.
-func (w *workerDelegate) PreReconcileHook(_ context.Context) error {
-	return nil
+func (w *workerDelegate) PreReconcileHook(ctx context.Context) error {
+	if err := w.checkIPAMReferences(ctx); err != nil {
+		return err
+	}
+	if err := w.recordWipedServers(ctx); err != nil {
+		return err
+	}
+	return w.updateServerCapacityCondition(ctx)
 }
 
 // PostReconcileHook implements genericactuator.WorkerDelegate.
-func (w *workerDelegate) PostReconcileHook(_ context.Context) error {
-	return nil
+func (w *workerDelegate) PostReconcileHook(ctx context.Context) error {
+	return w.waitForServersSanitized(ctx, w.removedPool)
 }
 
 // PreDeleteHook implements genericactuator.WorkerDelegate.
-func (w *workerDelegate) PreDeleteHook(_ context.Context) error {
-	return nil
+func (w *workerDelegate) PreDeleteHook(ctx context.Context) error {
+	return w.recordWipedServers(ctx)
 }
 
 // PostDeleteHook implements genericactuator.WorkerDelegate.
-func (w *workerDelegate) PostDeleteHook(_ context.Context) error {
-	return nil
+func (w *workerDelegate) PostDeleteHook(ctx context.Context) error {
+	if err := w.waitForServerClaimsReleased(ctx); err != nil {
+		return err
+	}
+	return w.waitForServersSanitized(ctx, allServers)
 }
@@ -13,6 +13,7 @@ import (
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/config"
 	apiv1alpha1 "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal/v1alpha1"
 )
 
@@ -34,7 +35,7 @@ var _ = Describe("MachinesImages", func() {
 
 		By("creating a worker delegate")
 		decoder := serializer.NewCodecFactory(k8sClient.Scheme(), serializer.EnableStrict).UniversalDecoder()
-		workerDelegate, err := NewWorkerDelegate(k8sClient, decoder, k8sClient.Scheme(), "", w, testCluster)
+		workerDelegate, err := NewWorkerDelegate(k8sClient, decoder, k8sClient.Scheme(), "", w, testCluster, config.TenantBootstrap{}, config.ServerSanitization{})
 		Expect(err).NotTo(HaveOccurred())
 
 		By("calling the updating machine image status")
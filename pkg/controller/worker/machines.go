@@ -4,30 +4,34 @@
 package worker
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/gardener/gardener/extensions/pkg/controller/worker"
 	genericworkeractuator "github.com/gardener/gardener/extensions/pkg/controller/worker/genericactuator"
 	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
-	"github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
 	machinecontrollerv1alpha1 "github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
-	"github.com/imdario/mergo"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/yaml"
 
 	metalv1alpha1 "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal/v1alpha1"
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/ignition"
 	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/metal"
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/metal/helper"
 )
 
 // DeployMachineClasses generates and creates the metal specific machine classes.
 func (w *workerDelegate) DeployMachineClasses(ctx context.Context) error {
-	machineClasses, machineClassSecrets, err := w.generateMachineClassAndSecrets(ctx)
+	machineClasses, machineClassSecrets, workerPoolHashes, err := w.generateMachineClassAndSecrets(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to generate machine classes and machine class secrets: %w", err)
 	}
@@ -44,7 +48,7 @@ func (w *workerDelegate) DeployMachineClasses(ctx context.Context) error {
 		}
 	}
 
-	return nil
+	return w.updateWorkerPoolHashes(ctx, workerPoolHashes)
 }
 
 // GenerateMachineDeployments generates the configuration for the desired machine deployments.
@@ -54,14 +58,23 @@ func (w *workerDelegate) GenerateMachineDeployments(ctx context.Context) (worker
 	)
 
 	for _, pool := range w.worker.Spec.Pools {
+		workerConfig, err := decodeWorkerConfig(w.decoder, pool)
+		if err != nil {
+			return nil, err
+		}
+		mergedIgnition, err := w.mergeIgnitionConfig(ctx, workerConfig)
+		if err != nil {
+			return nil, err
+		}
+		workerPoolHash, _, err := w.generateHashForWorkerPool(ctx, pool, workerConfig, mergedIgnition)
+		if err != nil {
+			return nil, err
+		}
+
 		zoneLen := int32(len(pool.Zones))
 		for zoneIndex := range pool.Zones {
-			workerPoolHash, err := w.generateHashForWorkerPool(pool)
-			if err != nil {
-				return nil, err
-			}
 			var (
-				deploymentName = fmt.Sprintf("%s-%s-z%d", w.worker.Namespace, pool.Name, zoneIndex+1)
+				deploymentName = w.deploymentName(pool, zoneIndex)
 				className      = fmt.Sprintf("%s-%s", deploymentName, workerPoolHash)
 			)
 			zoneIdx := int32(zoneIndex)
@@ -69,7 +82,7 @@ func (w *workerDelegate) GenerateMachineDeployments(ctx context.Context) (worker
 			machineDeployments = append(machineDeployments, worker.MachineDeployment{
 				Name:                 deploymentName,
 				ClassName:            className,
-				SecretName:           className,
+				SecretName:           w.machineClassSecretName(pool, workerPoolHash),
 				Minimum:              worker.DistributeOverZones(zoneIdx, pool.Minimum, zoneLen),
 				Maximum:              worker.DistributeOverZones(zoneIdx, pool.Maximum, zoneLen),
 				MaxSurge:             worker.DistributePositiveIntOrPercent(zoneIdx, pool.MaxSurge, zoneLen, pool.Maximum),
@@ -85,86 +98,152 @@ func (w *workerDelegate) GenerateMachineDeployments(ctx context.Context) (worker
 	return machineDeployments, nil
 }
 
-func (w *workerDelegate) generateMachineClassAndSecrets(ctx context.Context) ([]*machinecontrollerv1alpha1.MachineClass, []*corev1.Secret, error) {
+func (w *workerDelegate) generateMachineClassAndSecrets(ctx context.Context) ([]*machinecontrollerv1alpha1.MachineClass, []*corev1.Secret, []metalv1alpha1.WorkerPoolHash, error) {
 	var (
 		machineClasses      []*machinecontrollerv1alpha1.MachineClass
 		machineClassSecrets []*corev1.Secret
+		workerPoolHashes    []metalv1alpha1.WorkerPoolHash
 	)
 
 	for _, pool := range w.worker.Spec.Pools {
+		workerConfig, err := decodeWorkerConfig(w.decoder, pool)
+		if err != nil {
+			return nil, nil, nil, err
+		}
 
-		workerConfig := &metalv1alpha1.WorkerConfig{}
-		if pool.ProviderConfig != nil && pool.ProviderConfig.Raw != nil {
-			if _, _, err := w.decoder.Decode(pool.ProviderConfig.Raw, nil, workerConfig); err != nil {
-				return nil, nil, fmt.Errorf("could not decode provider config: %+v", err)
-			}
+		mergedIgnition, err := w.mergeIgnitionConfig(ctx, workerConfig)
+		if err != nil {
+			return nil, nil, nil, err
 		}
 
-		workerPoolHash, err := w.generateHashForWorkerPool(pool)
+		workerPoolHash, recordedHash, err := w.generateHashForWorkerPool(ctx, pool, workerConfig, mergedIgnition)
 		if err != nil {
-			return nil, nil, fmt.Errorf("failed to generate hash for worker pool %s: %w", pool.Name, err)
+			return nil, nil, nil, fmt.Errorf("failed to generate hash for worker pool %s: %w", pool.Name, err)
 		}
+		workerPoolHashes = append(workerPoolHashes, recordedHash)
 
 		arch := ptr.Deref(pool.Architecture, v1beta1constants.ArchitectureAMD64)
 		machineImage, err := w.findMachineImage(pool.MachineImage.Name, pool.MachineImage.Version, &arch)
 		if err != nil {
-			return nil, nil, err
+			return nil, nil, nil, err
 		}
 
-		serverLabels, err := w.getServerLabelsForMachine(pool.MachineType, workerConfig)
-		if err != nil {
-			return nil, nil, err
+		machineClassProviderSpec := map[string]any{
+			metal.ImageFieldName: machineImage,
 		}
 
-		machineClassProviderSpec := map[string]any{
-			metal.ImageFieldName:        machineImage,
-			metal.ServerLabelsFieldName: serverLabels,
+		hostnamePolicy := w.getHostnamePolicy(workerConfig)
+		ignitionData, err := machineIgnition(mergedIgnition, hostnamePolicy)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to generate ignition for machine pool %s: %w", pool.Name, err)
 		}
 
-		if workerConfig.ExtraIgnition != nil {
-			if mergedIgnition, err := w.mergeIgnitionConfig(ctx, workerConfig); err != nil {
-				return nil, nil, err
-			} else if mergedIgnition != "" {
-				machineClassProviderSpec[metal.IgnitionFieldName] = mergedIgnition
+		if ignitionData != "" {
+			machineClassProviderSpec[metal.IgnitionFieldName] = ignitionData
+			if workerConfig.ExtraIgnition != nil {
 				machineClassProviderSpec[metal.IgnitionOverrideFieldName] = workerConfig.ExtraIgnition.Override
 			}
 		}
 
-		if workerConfig.Metadata != nil {
-			machineClassProviderSpec[metal.MetaDataFieldName] = workerConfig.Metadata
+		if workerConfig.ServerSettings != nil {
+			machineClassProviderSpec[metal.ServerSettingsFieldName] = workerConfig.ServerSettings
+		}
+
+		if hostnamePolicy != nil {
+			machineClassProviderSpec[metal.HostnamePolicyFieldName] = hostnamePolicy
 		}
 
-		if workerConfig.IPAMConfig != nil {
-			machineClassProviderSpec[metal.IPAMConfigFieldName] = workerConfig.IPAMConfig
+		if workerConfig.ReleasePolicy != nil {
+			machineClassProviderSpec[metal.ReleasePolicyFieldName] = *workerConfig.ReleasePolicy
 		}
 
+		userData, err := worker.FetchUserData(ctx, w.client, w.worker.Namespace, pool)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		if compressUserData(workerConfig) {
+			if userData, err = encodeGzipBase64(userData); err != nil {
+				return nil, nil, nil, fmt.Errorf("failed to encode user data for machine pool %s: %w", pool.Name, err)
+			}
+			machineClassProviderSpec[metal.UserDataEncodingFieldName] = metal.EncodingGzipBase64
+
+			if ignitionData != "" {
+				encodedIgnition, err := encodeGzipBase64([]byte(ignitionData))
+				if err != nil {
+					return nil, nil, nil, fmt.Errorf("failed to encode ignition for machine pool %s: %w", pool.Name, err)
+				}
+				ignitionData = string(encodedIgnition)
+				machineClassProviderSpec[metal.IgnitionFieldName] = ignitionData
+				machineClassProviderSpec[metal.IgnitionEncodingFieldName] = metal.EncodingGzipBase64
+			}
+		}
+		if err := checkUserDataSize(userData, ignitionData); err != nil {
+			return nil, nil, nil, fmt.Errorf("invalid user data for machine pool %s: %w", pool.Name, err)
+		}
+
+		// The user data is the same for all zones of the pool, hence the MachineClasses of all zones share one Secret.
+		secretName := w.machineClassSecretName(pool, workerPoolHash)
+		machineClassSecrets = append(machineClassSecrets, &corev1.Secret{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Secret",
+				APIVersion: corev1.SchemeGroupVersion.String(),
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      secretName,
+				Namespace: w.worker.Namespace,
+				Labels:    map[string]string{v1beta1constants.GardenerPurpose: v1beta1constants.GardenPurposeMachineClass},
+			},
+			Data: map[string][]byte{
+				metal.UserDataFieldName: userData,
+			},
+		})
+
 		for zoneIndex, zone := range pool.Zones {
 			var (
-				deploymentName = fmt.Sprintf("%s-%s-z%d", w.worker.Namespace, pool.Name, zoneIndex+1)
+				deploymentName = w.deploymentName(pool, zoneIndex)
 				className      = fmt.Sprintf("%s-%s", deploymentName, workerPoolHash)
 			)
 
-			// Here we are going to create the necessary objects:
-			// 1. construct a MachineClass per zone containing the ProviderSpec needed by the MCM
-			// 2. construct a Secret for each MachineClass containing the user-data
-
-			nodeTemplate := &machinecontrollerv1alpha1.NodeTemplate{}
-			if pool.NodeTemplate != nil {
-				nodeTemplate = &machinecontrollerv1alpha1.NodeTemplate{
-					Capacity:     pool.NodeTemplate.Capacity,
-					InstanceType: pool.MachineType,
-					Region:       w.worker.Spec.Region,
-					Zone:         zone,
-				}
+			// Here we are going to construct a MachineClass per zone containing the ProviderSpec needed by the MCM,
+			// which references the Secret of the pool containing the user-data.
+
+			serverSelector, err := w.getServerSelectorForMachine(pool.MachineType, zone, workerConfig)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			machineClassProviderSpec[metal.ServerLabelsFieldName] = serverSelector.MatchLabels
+			if len(serverSelector.MatchExpressions) > 0 {
+				machineClassProviderSpec[metal.ServerSelectorFieldName] = serverSelector
+			} else {
+				delete(machineClassProviderSpec, metal.ServerSelectorFieldName)
+			}
+
+			ipamConfig := getIPAMConfigForZone(workerConfig, zone)
+			if ipamConfig != nil {
+				machineClassProviderSpec[metal.IPAMConfigFieldName] = ipamConfig
+			} else {
+				delete(machineClassProviderSpec, metal.IPAMConfigFieldName)
+			}
+
+			selector, err := metav1.LabelSelectorAsSelector(serverSelector)
+			if err != nil {
+				return nil, nil, nil, fmt.Errorf("invalid server selector for machine pool %s: %w", pool.Name, err)
+			}
+			nodeTemplate := w.generateNodeTemplate(ctx, pool, zone, selector)
+
+			if metadata := helper.RenderMetadata(workerConfig.Metadata, w.metadataTemplateData(pool, zone)); metadata != nil {
+				machineClassProviderSpec[metal.MetaDataFieldName] = metadata
 			}
 
 			machineClassProviderSpec[metal.LabelsFieldName] = map[string]string{
 				metal.ClusterNameLabel: w.cluster.ObjectMeta.Name,
+				metal.WorkerPoolLabel:  pool.Name,
 			}
 
 			machineClassProviderSpecJSON, err := json.Marshal(machineClassProviderSpec)
 			if err != nil {
-				return nil, nil, fmt.Errorf("failed to marshal machine class for machine pool %s: %w", pool.Name, err)
+				return nil, nil, nil, fmt.Errorf("failed to marshal machine class for machine pool %s: %w", pool.Name, err)
 			}
 
 			machineClass := &machinecontrollerv1alpha1.MachineClass{
@@ -186,109 +265,192 @@ func (w *workerDelegate) generateMachineClassAndSecrets(ctx context.Context) ([]
 				},
 				Provider: metal.Type,
 				SecretRef: &corev1.SecretReference{
-					Name:      className,
+					Name:      secretName,
 					Namespace: w.worker.Namespace,
 				},
 			}
-
-			userData, err := worker.FetchUserData(ctx, w.client, w.worker.Namespace, pool)
-			if err != nil {
-				return nil, nil, err
-			}
-
-			machineClassSecret := &corev1.Secret{
-				TypeMeta: metav1.TypeMeta{
-					Kind:       "Secret",
-					APIVersion: corev1.SchemeGroupVersion.String(),
-				},
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      className,
-					Namespace: w.worker.Namespace,
-					Labels:    map[string]string{v1beta1constants.GardenerPurpose: v1beta1constants.GardenPurposeMachineClass},
-				},
-				Data: map[string][]byte{
-					metal.UserDataFieldName: userData,
-				},
+			if err := checkMachineClassSize(machineClass); err != nil {
+				return nil, nil, nil, fmt.Errorf("invalid machine class for zone %s of machine pool %s: %w", zone, pool.Name, err)
 			}
 
 			machineClasses = append(machineClasses, machineClass)
-			machineClassSecrets = append(machineClassSecrets, machineClassSecret)
 		}
 	}
 
-	return machineClasses, machineClassSecrets, nil
+	return machineClasses, machineClassSecrets, workerPoolHashes, nil
 }
 
-func (w *workerDelegate) generateHashForWorkerPool(pool v1alpha1.WorkerPool) (string, error) {
-	// Generate the worker pool hash.
-	return worker.WorkerPoolHash(pool, w.cluster, nil, nil)
+func (w *workerDelegate) getServerSelectorForMachine(machineType, zone string, workerConfig *metalv1alpha1.WorkerConfig) (*metav1.LabelSelector, error) {
+	serverSelector := helper.ServerSelectorForMachine(w.cloudProfileConfig, w.worker.Spec.Region, zone, machineType, workerConfig.ExtraServerLabels, workerConfig.ServerSelector)
+	if len(serverSelector.MatchLabels) == 0 && len(serverSelector.MatchExpressions) == 0 {
+		return nil, fmt.Errorf("no server labels or selector found for machine type %s or worker config", machineType)
+	}
+	return serverSelector, nil
 }
 
-func (w *workerDelegate) getServerLabelsForMachine(machineType string, workerConfig *metalv1alpha1.WorkerConfig) (map[string]string, error) {
-	combinedLabels := make(map[string]string)
-	for _, t := range w.cloudProfileConfig.MachineTypes {
-		if t.Name == machineType {
-			for key, value := range t.ServerLabels {
-				combinedLabels[key] = value
-			}
+// getIPAMConfigForZone returns the IPAMConfig of the worker pool for the given zone. An entry of the ZoneIPAMConfig of
+// the zone replaces the entry of the worker pool's IPAMConfig with the same metadata key.
+func getIPAMConfigForZone(workerConfig *metalv1alpha1.WorkerConfig, zone string) []metalv1alpha1.IPAMConfig {
+	var zoneIPAMConfig []metalv1alpha1.IPAMConfig
+	for _, zoneConfig := range workerConfig.ZoneIPAMConfigs {
+		if zoneConfig.Zone == zone {
+			zoneIPAMConfig = zoneConfig.IPAMConfig
 			break
 		}
 	}
-	for key, value := range workerConfig.ExtraServerLabels {
-		combinedLabels[key] = value
+	if len(zoneIPAMConfig) == 0 {
+		return workerConfig.IPAMConfig
 	}
-	if len(combinedLabels) == 0 {
-		return nil, fmt.Errorf("no server labels found for machine type %s or worker config", machineType)
+
+	var ipamConfig []metalv1alpha1.IPAMConfig
+	for _, poolConfig := range workerConfig.IPAMConfig {
+		if !slices.ContainsFunc(zoneIPAMConfig, func(config metalv1alpha1.IPAMConfig) bool {
+			return config.MetadataKey == poolConfig.MetadataKey
+		}) {
+			ipamConfig = append(ipamConfig, poolConfig)
+		}
 	}
-	return combinedLabels, nil
+	return append(ipamConfig, zoneIPAMConfig...)
 }
 
-func (w *workerDelegate) mergeIgnitionConfig(ctx context.Context, workerConfig *metalv1alpha1.WorkerConfig) (string, error) {
-	rawIgnition := &map[string]interface{}{}
+// checkIPAMReferences ensures that the IPAM objects referenced by the IPAM configs of all zones of all worker pools
+// exist in the metal cluster. It is skipped for Workers in deletion, whose machines are removed regardless.
+func (w *workerDelegate) checkIPAMReferences(ctx context.Context) error {
+	if w.worker.DeletionTimestamp != nil {
+		return nil
+	}
 
-	if workerConfig.ExtraIgnition.Raw != "" {
-		if err := yaml.Unmarshal([]byte(workerConfig.ExtraIgnition.Raw), rawIgnition); err != nil {
-			return "", err
+	for _, pool := range w.worker.Spec.Pools {
+		workerConfig, err := decodeWorkerConfig(w.decoder, pool)
+		if err != nil {
+			return err
+		}
+		for _, zone := range pool.Zones {
+			if err := w.checkIPAMObjects(ctx, getIPAMConfigForZone(workerConfig, zone)); err != nil {
+				return fmt.Errorf("invalid ipam config for zone %s of machine pool %s: %w", zone, pool.Name, err)
+			}
 		}
 	}
+	return nil
+}
 
-	if workerConfig.ExtraIgnition.SecretRef != nil {
-		secret := &corev1.Secret{}
-		secretKey := client.ObjectKey{Namespace: w.worker.Namespace, Name: workerConfig.ExtraIgnition.SecretRef.Name}
-		if err := w.client.Get(ctx, secretKey, secret); err != nil {
-			return "", fmt.Errorf("failed to get ignition secret %s: %w", workerConfig.ExtraIgnition.SecretRef, err)
+// checkIPAMObjects ensures that the IPAM objects referenced by the given IPAMConfig exist in the namespace of the
+// worker in the metal cluster.
+func (w *workerDelegate) checkIPAMObjects(ctx context.Context, ipamConfig []metalv1alpha1.IPAMConfig) error {
+	if len(ipamConfig) == 0 {
+		return nil
+	}
+
+	metalClient, namespace, err := w.getMetalClient(ctx)
+	if err != nil {
+		return err
+	}
+	for _, config := range ipamConfig {
+		if config.IPAMRef == nil {
+			continue
 		}
+		ref := config.IPAMRef
+		if _, err := metal.GetIPAMObject(ctx, metalClient, namespace, ref.APIGroup, ref.Kind, ref.Name); err != nil {
+			if apierrors.IsNotFound(err) {
+				return fmt.Errorf("%s %s referenced by metadata key %s not found in namespace %s", ref.Kind, ref.Name, config.MetadataKey, namespace)
+			}
+			return fmt.Errorf("failed to get %s %s referenced by metadata key %s: %w", ref.Kind, ref.Name, config.MetadataKey, err)
+		}
+	}
+	return nil
+}
 
-		secretContent, ok := secret.Data[metal.IgnitionFieldName]
-		if !ok {
-			return "", fmt.Errorf("ignition key not found in secret %s", workerConfig.ExtraIgnition.SecretRef)
+// metadataTemplateData returns the values the metadata templates of the WorkerConfig are rendered with for the given
+// worker pool zone.
+func (w *workerDelegate) metadataTemplateData(pool extensionsv1alpha1.WorkerPool, zone string) helper.MetadataTemplateData {
+	data := helper.MetadataTemplateData{
+		PoolName:            pool.Name,
+		Zone:                zone,
+		Region:              w.worker.Spec.Region,
+		KubernetesVersion:   ptr.Deref(pool.KubernetesVersion, ""),
+		MachineImageName:    pool.MachineImage.Name,
+		MachineImageVersion: pool.MachineImage.Version,
+	}
+	if w.cluster != nil && w.cluster.Shoot != nil {
+		data.ShootName = w.cluster.Shoot.Name
+		// The namespace of the Worker is named after the technical ID of the Shoot.
+		data.ProjectName = projectNameFromTechnicalID(cmp.Or(w.cluster.Shoot.Status.TechnicalID, w.worker.Namespace))
+		if data.KubernetesVersion == "" {
+			data.KubernetesVersion = w.cluster.Shoot.Spec.Kubernetes.Version
 		}
+	}
+	return data
+}
 
-		ignitionSecret := map[string]interface{}{}
+// projectNameFromTechnicalID returns the name of the project contained in the given technical ID of a Shoot, which
+// has the form shoot--<project>--<name>. Neither name may contain two consecutive hyphens. The project name cannot be
+// determined from the legacy form shoot-<project>-<name>, an empty name is returned for it.
+func projectNameFromTechnicalID(technicalID string) string {
+	rest, ok := strings.CutPrefix(technicalID, v1beta1constants.TechnicalIDPrefix+"-")
+	if !ok {
+		return ""
+	}
+	projectName, _, ok := strings.Cut(rest, "--")
+	if !ok {
+		return ""
+	}
+	return projectName
+}
 
-		if err := yaml.Unmarshal(secretContent, &ignitionSecret); err != nil {
-			return "", err
-		}
+// getHostnamePolicy returns the hostname policy of the WorkerConfig or, if not set, the one of the CloudProfile.
+func (w *workerDelegate) getHostnamePolicy(workerConfig *metalv1alpha1.WorkerConfig) *metalv1alpha1.HostnamePolicy {
+	if workerConfig.HostnamePolicy != nil {
+		return workerConfig.HostnamePolicy
+	}
+	if w.cloudProfileConfig == nil || w.cloudProfileConfig.HostnamePolicy == nil {
+		return nil
+	}
+	return &metalv1alpha1.HostnamePolicy{
+		Source:   metalv1alpha1.HostnameSource(w.cloudProfileConfig.HostnamePolicy.Source),
+		Template: w.cloudProfileConfig.HostnamePolicy.Template,
+		Domain:   w.cloudProfileConfig.HostnamePolicy.Domain,
+	}
+}
 
-		// append ignition
-		opt := mergo.WithAppendSlice
+// mergeIgnitionConfig merges the Ignition config generated from the storage layout of the WorkerConfig, the Ignition
+// config of the ignition secret and the inline Ignition config of the WorkerConfig, in this order. Entries of later
+// configs take precedence over entries with the same identity in earlier ones.
+func (w *workerDelegate) mergeIgnitionConfig(ctx context.Context, workerConfig *metalv1alpha1.WorkerConfig) (string, error) {
+	var configs []ignition.Config
 
-		// merge both ignitions
-		err := mergo.Merge(rawIgnition, ignitionSecret, opt)
+	if workerConfig.Storage != nil {
+		storageConfig, err := storageIgnition(workerConfig.Storage).Config()
 		if err != nil {
-			return "", err
+			return "", fmt.Errorf("failed to generate storage ignition: %w", err)
 		}
+		configs = append(configs, storageConfig)
 	}
 
-	// avoid converting empty string to an empty map with non-zero length
-	if len(*rawIgnition) == 0 {
-		return "", nil
+	if secretName := ignitionSecretName(w.cluster, workerConfig); secretName != "" {
+		secret := &corev1.Secret{}
+		if err := w.client.Get(ctx, client.ObjectKey{Namespace: w.worker.Namespace, Name: secretName}, secret); err != nil {
+			return "", fmt.Errorf("failed to get ignition secret %s: %w", secretName, err)
+		}
+
+		secretContent, ok := secret.Data[metal.IgnitionFieldName]
+		if !ok {
+			return "", fmt.Errorf("ignition key not found in secret %s", secretName)
+		}
+
+		secretIgnition, err := ignition.Parse(secretContent)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse ignition of secret %s: %w", secretName, err)
+		}
+		configs = append(configs, secretIgnition)
 	}
 
-	mergedIgnition, err := yaml.Marshal(rawIgnition)
-	if err != nil {
-		return "", err
+	if workerConfig.ExtraIgnition != nil && workerConfig.ExtraIgnition.Raw != "" {
+		rawIgnition, err := ignition.Parse([]byte(workerConfig.ExtraIgnition.Raw))
+		if err != nil {
+			return "", fmt.Errorf("failed to parse raw ignition: %w", err)
+		}
+		configs = append(configs, rawIgnition)
 	}
 
-	return string(mergedIgnition), nil
+	return ignition.Marshal(ignition.Merge(configs...))
 }
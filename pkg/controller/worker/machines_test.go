@@ -6,10 +6,12 @@ package worker
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/gardener/gardener/extensions/pkg/controller/worker"
 	genericworkeractuator "github.com/gardener/gardener/extensions/pkg/controller/worker/genericactuator"
 	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	"github.com/gardener/gardener/pkg/utils"
 	machinecontrollerv1alpha1 "github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -17,39 +19,69 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	. "sigs.k8s.io/controller-runtime/pkg/envtest/komega"
 
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/config"
+	apiv1alpha1 "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal/v1alpha1"
 	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/metal"
 )
 
 var _ = Describe("Machines", func() {
 	ns, _ := SetupTest()
 
+	dataYml := map[string]any{
+		"a": map[string]any{
+			"b": "foo",
+			"c": "bar",
+		},
+	}
+	yamlString, err := mapToString(dataYml)
+	Expect(err).NotTo(HaveOccurred())
+	ignitionHash := utils.ComputeSHA256Hex([]byte(yamlString))
+
+	// expectedWorkerPoolHash returns the current version of the worker pool hash of the test pool.
+	expectedWorkerPoolHash := func() string {
+		data := []string{ignitionHash}
+		for _, matchLabels := range []map[string]string{
+			{"foo": "bar", "foo1": "bar1", "rack": "rack1"},
+			{"foo": "bar", "foo1": "bar1"},
+		} {
+			selector, err := json.Marshal(&metav1.LabelSelector{MatchLabels: matchLabels, MatchExpressions: workerConfig.ServerSelector.MatchExpressions})
+			Expect(err).NotTo(HaveOccurred())
+			data = append(data, string(selector))
+		}
+		metadata, err := json.Marshal(workerConfig.Metadata)
+		Expect(err).NotTo(HaveOccurred())
+		data = append(data, string(metadata))
+		serverSettings, err := json.Marshal(workerConfig.ServerSettings)
+		Expect(err).NotTo(HaveOccurred())
+		data = append(data, string(serverSettings))
+
+		workerPoolHash, err := worker.WorkerPoolHash(pool, testCluster, data, data)
+		Expect(err).NotTo(HaveOccurred())
+		return workerPoolHash
+	}
+
 	When("deploying machine classes", func() {
 
 		var (
 			deploymentName     string
 			className          string
+			secretName         string
 			machineClass       *machinecontrollerv1alpha1.MachineClass
 			machineClassSecret *corev1.Secret
 			workerDelegate     genericworkeractuator.WorkerDelegate
 		)
 
-		dataYml := map[string]any{
-			"a": map[string]any{
-				"b": "foo",
-				"c": "bar",
-			},
-		}
-		yamlString, err := mapToString(dataYml)
-		Expect(err).NotTo(HaveOccurred())
-
 		BeforeEach(func(ctx SpecContext) {
-			// TODO: Fix machine pool hashing
-			workerPoolHash, err := worker.WorkerPoolHash(pool, testCluster, nil, nil)
-			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Create(ctx, w)).To(Succeed())
+			DeferCleanup(k8sClient.Delete, w)
+
+			workerPoolHash := expectedWorkerPoolHash()
 			deploymentName = fmt.Sprintf("%s-%s-z%d", ns.Name, pool.Name, 1)
 			className = fmt.Sprintf("%s-%s", deploymentName, workerPoolHash)
+			secretName = fmt.Sprintf("%s-%s-%s", ns.Name, pool.Name, workerPoolHash)
 			machineClass = &machinecontrollerv1alpha1.MachineClass{
 				ObjectMeta: metav1.ObjectMeta{
 					Namespace: ns.Name,
@@ -59,12 +91,12 @@ var _ = Describe("Machines", func() {
 			machineClassSecret = &corev1.Secret{
 				ObjectMeta: metav1.ObjectMeta{
 					Namespace: ns.Name,
-					Name:      className,
+					Name:      secretName,
 				},
 			}
 			By("deploying the machine class for a given multi zone cluster")
 			decoder := serializer.NewCodecFactory(k8sClient.Scheme(), serializer.EnableStrict).UniversalDecoder()
-			workerDelegate, err = NewWorkerDelegate(k8sClient, decoder, k8sClient.Scheme(), "", w, testCluster)
+			workerDelegate, err = NewWorkerDelegate(k8sClient, decoder, k8sClient.Scheme(), "", w, testCluster, config.TenantBootstrap{}, config.ServerSanitization{})
 			Expect(err).NotTo(HaveOccurred())
 		})
 
@@ -76,21 +108,49 @@ var _ = Describe("Machines", func() {
 		It("should create the expected machine class for a multi zone cluster", func(ctx SpecContext) {
 			Expect(workerDelegate.DeployMachineClasses(ctx)).To(Succeed())
 			By("ensuring that the machine class for each pool has been deployed")
+			machineIgnitionString, err := mapToString(map[string]any{
+				"a": dataYml["a"],
+				"systemd": map[string]any{
+					"units": []any{map[string]any{
+						"name": "kubelet.service",
+						"dropins": []any{map[string]any{
+							"name":     "10-hostname.conf",
+							"contents": "[Service]\nExecStartPre=/bin/sh -c 'hostnamectl set-hostname $(hostname -f)'\n",
+						}},
+					}},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
 			machineClassProviderSpec := map[string]any{
 				"image": "registry/my-os",
 				"labels": map[string]any{
 					metal.ClusterNameLabel: testCluster.ObjectMeta.Name,
+					metal.WorkerPoolLabel:  pool.Name,
 				},
 				metal.ServerLabelsFieldName: map[string]string{
 					"foo":  "bar",
 					"foo1": "bar1",
+					"rack": "rack1",
+				},
+				metal.ServerSelectorFieldName: &metav1.LabelSelector{
+					MatchLabels: map[string]string{
+						"foo":  "bar",
+						"foo1": "bar1",
+						"rack": "rack1",
+					},
+					MatchExpressions: []metav1.LabelSelectorRequirement{
+						{Key: "maintenance", Operator: metav1.LabelSelectorOpDoesNotExist},
+					},
 				},
-				metal.IgnitionFieldName:         yamlString,
+				metal.IgnitionFieldName:         machineIgnitionString,
 				metal.IgnitionOverrideFieldName: true,
 				metal.MetaDataFieldName: map[string]string{
-					"foo": "bar",
-					"baz": "100",
+					"foo":  "bar",
+					"baz":  "100",
+					"zone": "zone1",
 				},
+				metal.ServerSettingsFieldName: workerConfig.ServerSettings,
+				metal.ReleasePolicyFieldName:  apiv1alpha1.ReleasePolicyWipe,
 			}
 
 			Eventually(Object(machineClass)).Should(SatisfyAll(
@@ -100,7 +160,7 @@ var _ = Describe("Machines", func() {
 				}),
 				HaveField("SecretRef", &corev1.SecretReference{
 					Namespace: ns.Name,
-					Name:      className,
+					Name:      secretName,
 				}),
 				HaveField("Provider", "ironcore-metal"),
 				HaveField("NodeTemplate", &machinecontrollerv1alpha1.NodeTemplate{
@@ -114,7 +174,7 @@ var _ = Describe("Machines", func() {
 				}),
 			))
 
-			By("ensuring that the machine class secret have been applied")
+			By("ensuring that the machine class secret shared by all zones has been applied")
 
 			Eventually(Object(machineClassSecret)).Should(SatisfyAll(
 				HaveField("ObjectMeta.Labels", HaveKeyWithValue(v1beta1constants.GardenerPurpose, v1beta1constants.GardenPurposeMachineClass)),
@@ -125,16 +185,16 @@ var _ = Describe("Machines", func() {
 
 	It("should generate the machine deployments", func(ctx SpecContext) {
 		By("creating a worker delegate")
-		workerPoolHash, err := worker.WorkerPoolHash(pool, testCluster, nil, nil)
-		Expect(err).NotTo(HaveOccurred())
+		workerPoolHash := expectedWorkerPoolHash()
 		var (
 			deploymentName1 = fmt.Sprintf("%s-%s-z%d", w.Namespace, pool.Name, 1)
 			deploymentName2 = fmt.Sprintf("%s-%s-z%d", w.Namespace, pool.Name, 2)
 			className1      = fmt.Sprintf("%s-%s", deploymentName1, workerPoolHash)
 			className2      = fmt.Sprintf("%s-%s", deploymentName2, workerPoolHash)
+			secretName      = fmt.Sprintf("%s-%s-%s", w.Namespace, pool.Name, workerPoolHash)
 		)
 		decoder := serializer.NewCodecFactory(k8sClient.Scheme(), serializer.EnableStrict).UniversalDecoder()
-		workerDelegate, err := NewWorkerDelegate(k8sClient, decoder, k8sClient.Scheme(), "", w, testCluster)
+		workerDelegate, err := NewWorkerDelegate(k8sClient, decoder, k8sClient.Scheme(), "", w, testCluster, config.TenantBootstrap{}, config.ServerSanitization{})
 		Expect(err).NotTo(HaveOccurred())
 
 		By("generating the machine deployments")
@@ -145,7 +205,7 @@ var _ = Describe("Machines", func() {
 			worker.MachineDeployment{
 				Name:                 deploymentName1,
 				ClassName:            className1,
-				SecretName:           className1,
+				SecretName:           secretName,
 				Minimum:              worker.DistributeOverZones(0, pool.Minimum, 2),
 				Maximum:              worker.DistributeOverZones(0, pool.Maximum, 2),
 				MaxSurge:             worker.DistributePositiveIntOrPercent(0, pool.MaxSurge, 2, pool.Maximum),
@@ -158,7 +218,7 @@ var _ = Describe("Machines", func() {
 			worker.MachineDeployment{
 				Name:                 deploymentName2,
 				ClassName:            className2,
-				SecretName:           className2,
+				SecretName:           secretName,
 				Minimum:              worker.DistributeOverZones(1, pool.Minimum, 2),
 				Maximum:              worker.DistributeOverZones(1, pool.Maximum, 2),
 				MaxSurge:             worker.DistributePositiveIntOrPercent(1, pool.MaxSurge, 2, pool.Maximum),
@@ -170,6 +230,105 @@ var _ = Describe("Machines", func() {
 			},
 		}))
 	})
+
+	It("should keep the worker pool hash version of existing machine deployments", func(ctx SpecContext) {
+		Expect(k8sClient.Create(ctx, w)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, w)
+
+		By("creating a machine deployment with a class of the hash without metal specific data")
+		legacyHash, err := worker.WorkerPoolHash(pool, testCluster, nil, nil)
+		Expect(err).NotTo(HaveOccurred())
+		deploymentName := fmt.Sprintf("%s-%s-z%d", w.Namespace, pool.Name, 1)
+		machineDeployment := &machinecontrollerv1alpha1.MachineDeployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: w.Namespace,
+				Name:      deploymentName,
+			},
+			Spec: machinecontrollerv1alpha1.MachineDeploymentSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"name": deploymentName}},
+				Template: machinecontrollerv1alpha1.MachineTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"name": deploymentName}},
+					Spec: machinecontrollerv1alpha1.MachineSpec{
+						Class: machinecontrollerv1alpha1.ClassSpec{
+							Kind: "MachineClass",
+							Name: fmt.Sprintf("%s-%s", deploymentName, legacyHash),
+						},
+					},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, machineDeployment)).To(Succeed())
+
+		workerDelegate := NewTestWorkerDelegate()
+
+		By("deploying the machine classes and generating the machine deployments")
+		Expect(workerDelegate.DeployMachineClasses(ctx)).To(Succeed())
+		machineDeployments, err := workerDelegate.GenerateMachineDeployments(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(machineDeployments).To(HaveLen(2))
+		Expect(machineDeployments[0].ClassName).To(Equal(fmt.Sprintf("%s-%s", deploymentName, legacyHash)))
+		Expect(machineDeployments[1].ClassName).To(HaveSuffix(legacyHash))
+
+		By("keeping the recorded hash version without looking at the machine deployments")
+		Expect(k8sClient.Delete(ctx, machineDeployment)).To(Succeed())
+		Expect(workerDelegate.DeployMachineClasses(ctx)).To(Succeed())
+		machineDeployments, err = workerDelegate.GenerateMachineDeployments(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(machineDeployments[0].ClassName).To(Equal(fmt.Sprintf("%s-%s", deploymentName, legacyHash)))
+
+		workerStatus, err := workerDelegate.decodeWorkerProviderStatus()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(workerStatus.WorkerPoolHashes).To(ConsistOf(SatisfyAll(
+			HaveField("Pool", pool.Name),
+			HaveField("Version", workerPoolHashVersionLegacy),
+		)))
+
+		By("rolling the pool to the current hash version once a metal specific setting changes")
+		ignitionSecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: ns.Name, Name: workerConfig.ExtraIgnition.SecretRef.Name}}
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(ignitionSecret), ignitionSecret)).To(Succeed())
+		ignitionSecret.Data["ignition"] = []byte("a:\n  c: baz\n")
+		Expect(k8sClient.Update(ctx, ignitionSecret)).To(Succeed())
+
+		Expect(workerDelegate.DeployMachineClasses(ctx)).To(Succeed())
+		machineDeployments, err = workerDelegate.GenerateMachineDeployments(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(machineDeployments[0].ClassName).NotTo(HaveSuffix(legacyHash))
+
+		workerStatus, err = workerDelegate.decodeWorkerProviderStatus()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(workerStatus.WorkerPoolHashes).To(ConsistOf(SatisfyAll(
+			HaveField("Pool", pool.Name),
+			HaveField("Version", workerPoolHashVersionCurrent),
+		)))
+	})
+})
+
+var _ = Describe("#getIPAMConfigForZone", func() {
+	ipamConfig := func(key, name string) apiv1alpha1.IPAMConfig {
+		return apiv1alpha1.IPAMConfig{
+			MetadataKey: key,
+			IPAMRef:     &apiv1alpha1.IPAMObjectReference{Name: name, APIGroup: metal.IPAMGroup, Kind: metal.NetworkKind},
+		}
+	}
+
+	workerConfig := &apiv1alpha1.WorkerConfig{
+		IPAMConfig: []apiv1alpha1.IPAMConfig{ipamConfig("net", "net"), ipamConfig("storage", "storage")},
+		ZoneIPAMConfigs: []apiv1alpha1.ZoneIPAMConfig{
+			{Zone: "zone1", IPAMConfig: []apiv1alpha1.IPAMConfig{ipamConfig("net", "net-zone1"), ipamConfig("bmc", "bmc-zone1")}},
+		},
+	}
+
+	It("should replace the pool entries with the entries of the zone", func() {
+		Expect(getIPAMConfigForZone(workerConfig, "zone1")).To(Equal([]apiv1alpha1.IPAMConfig{
+			ipamConfig("storage", "storage"),
+			ipamConfig("net", "net-zone1"),
+			ipamConfig("bmc", "bmc-zone1"),
+		}))
+	})
+
+	It("should return the pool entries for zones without override", func() {
+		Expect(getIPAMConfigForZone(workerConfig, "zone2")).To(Equal(workerConfig.IPAMConfig))
+	})
 })
 
 func encodeMap(m map[string]any) []byte {
@@ -177,3 +336,53 @@ func encodeMap(m map[string]any) []byte {
 	Expect(err).To(Succeed())
 	return data
 }
+
+var _ = Describe("#checkIPAMReferences", func() {
+	ns, _ := SetupTest()
+
+	var (
+		delegate       *workerDelegate
+		metalNamespace *corev1.Namespace
+	)
+
+	BeforeEach(func(ctx SpecContext) {
+		metalNamespace = SetupMetalNamespace(ctx, ns)
+
+		workerConfig.IPAMConfig = []apiv1alpha1.IPAMConfig{{
+			MetadataKey: "net",
+			IPAMRef:     &apiv1alpha1.IPAMObjectReference{Name: "net", APIGroup: metal.IPAMGroup, Kind: metal.NetworkKind},
+		}}
+		data, err := json.Marshal(workerConfig)
+		Expect(err).NotTo(HaveOccurred())
+		w.Spec.Pools[0].ProviderConfig = &runtime.RawExtension{Raw: data}
+
+		delegate = NewTestWorkerDelegate()
+	})
+
+	It("should fail if a referenced IPAM object does not exist", func(ctx SpecContext) {
+		Expect(delegate.checkIPAMReferences(ctx)).To(MatchError(ContainSubstring("Network net referenced by metadata key net not found in namespace " + metalNamespace.Name)))
+	})
+
+	It("should succeed if the referenced IPAM objects exist", func(ctx SpecContext) {
+		network := metal.NewUnstructured(metal.NetworkGVK, metalNamespace.Name, "net")
+		Expect(k8sClient.Create(ctx, network)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, network)
+
+		Expect(delegate.checkIPAMReferences(ctx)).To(Succeed())
+	})
+
+	It("should skip the check for a worker in deletion", func(ctx SpecContext) {
+		w.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+
+		Expect(delegate.checkIPAMReferences(ctx)).To(Succeed())
+	})
+})
+
+var _ = DescribeTable("#projectNameFromTechnicalID",
+	func(technicalID, projectName string) {
+		Expect(projectNameFromTechnicalID(technicalID)).To(Equal(projectName))
+	},
+	Entry("technical ID", "shoot--my-project--my-shoot", "my-project"),
+	Entry("legacy technical ID", "shoot-my-project-my-shoot", ""),
+	Entry("no technical ID", "", ""),
+)
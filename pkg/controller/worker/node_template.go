@@ -0,0 +1,120 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package worker
+
+import (
+	"context"
+
+	"github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	machinecontrollerv1alpha1 "github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/ptr"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/metal"
+)
+
+// resourceGPU is the name of the GPU resource in the capacity of a NodeTemplate.
+const resourceGPU corev1.ResourceName = "gpu"
+
+// generateNodeTemplate returns the NodeTemplate of the MachineClass of a worker pool zone. A NodeTemplate of the worker
+// pool is used as is, otherwise it is derived from the servers matching the server selector of the zone or, if no
+// server has reported its hardware, from the machine type in the CloudProfile.
+func (w *workerDelegate) generateNodeTemplate(ctx context.Context, pool v1alpha1.WorkerPool, zone string, serverSelector labels.Selector) *machinecontrollerv1alpha1.NodeTemplate {
+	nodeTemplate := &machinecontrollerv1alpha1.NodeTemplate{
+		InstanceType: pool.MachineType,
+		Region:       w.worker.Spec.Region,
+		Zone:         zone,
+	}
+
+	if pool.NodeTemplate != nil {
+		nodeTemplate.Capacity = pool.NodeTemplate.Capacity
+		return nodeTemplate
+	}
+
+	capacity, architecture := w.capacityFromServers(ctx, serverSelector)
+	if capacity == nil {
+		capacity, architecture = w.capacityFromMachineType(pool.MachineType)
+	}
+	if capacity == nil {
+		return &machinecontrollerv1alpha1.NodeTemplate{}
+	}
+	if architecture == "" {
+		architecture = ptr.Deref(pool.Architecture, "")
+	}
+
+	nodeTemplate.Capacity = capacity
+	if architecture != "" {
+		nodeTemplate.Architecture = ptr.To(architecture)
+	}
+	return nodeTemplate
+}
+
+// capacityFromServers returns the smallest capacity of the servers matching the server selector, so that the
+// cluster-autoscaler never expects more resources than a new node provides.
+func (w *workerDelegate) capacityFromServers(ctx context.Context, serverSelector labels.Selector) (corev1.ResourceList, string) {
+	log := logf.FromContext(ctx)
+
+	serverReader, err := w.getServerReader(ctx)
+	if err != nil {
+		log.V(1).Info("Could not derive node template from servers", "reason", err.Error())
+		return nil, ""
+	}
+	servers, err := metal.ListServers(ctx, serverReader, serverSelector)
+	if err != nil {
+		log.V(1).Info("Could not derive node template from servers", "reason", err.Error())
+		return nil, ""
+	}
+
+	var (
+		capacity     corev1.ResourceList
+		architecture string
+	)
+	for _, server := range servers {
+		serverCapacity, ok := metal.GetServerCapacity(&server)
+		if !ok {
+			continue
+		}
+		if capacity == nil {
+			capacity = corev1.ResourceList{
+				corev1.ResourceCPU:    serverCapacity.CPU,
+				corev1.ResourceMemory: serverCapacity.Memory,
+			}
+			architecture = serverCapacity.Architecture
+			continue
+		}
+		if serverCapacity.CPU.Cmp(capacity[corev1.ResourceCPU]) < 0 {
+			capacity[corev1.ResourceCPU] = serverCapacity.CPU
+		}
+		if serverCapacity.Memory.Cmp(capacity[corev1.ResourceMemory]) < 0 {
+			capacity[corev1.ResourceMemory] = serverCapacity.Memory
+		}
+		if architecture != serverCapacity.Architecture {
+			architecture = ""
+		}
+	}
+	return capacity, architecture
+}
+
+// capacityFromMachineType returns the capacity of the machine type in the CloudProfile.
+func (w *workerDelegate) capacityFromMachineType(machineType string) (corev1.ResourceList, string) {
+	if w.cluster.CloudProfile == nil {
+		return nil, ""
+	}
+	for _, t := range w.cluster.CloudProfile.Spec.MachineTypes {
+		if t.Name != machineType {
+			continue
+		}
+		capacity := corev1.ResourceList{
+			corev1.ResourceCPU:    t.CPU,
+			corev1.ResourceMemory: t.Memory,
+		}
+		if !t.GPU.IsZero() {
+			capacity[resourceGPU] = t.GPU
+		}
+		return capacity, ptr.Deref(t.Architecture, "")
+	}
+	return nil, ""
+}
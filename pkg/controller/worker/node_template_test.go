@@ -0,0 +1,104 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package worker
+
+import (
+	"fmt"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	gardenerextensionv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	machinecontrollerv1alpha1 "github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/ptr"
+
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/metal"
+)
+
+var _ = Describe("NodeTemplate", func() {
+	ns, _ := SetupTest()
+
+	var (
+		delegate     *workerDelegate
+		serverLabels map[string]string
+	)
+
+	BeforeEach(func(ctx SpecContext) {
+		SetupMetalNamespace(ctx, ns)
+
+		pool.NodeTemplate = nil
+		serverLabels = map[string]string{"rack": ns.Name}
+
+		testCluster.CloudProfile.Spec.MachineTypes = []gardencorev1beta1.MachineType{{
+			Name:         "large",
+			CPU:          resource.MustParse("8"),
+			Memory:       resource.MustParse("32Gi"),
+			Architecture: ptr.To("arm64"),
+		}}
+
+		delegate = NewTestWorkerDelegate()
+	})
+
+	createServer := func(ctx SpecContext, name, memory string, threads int64) {
+		server := metal.NewUnstructured(metal.ServerGVK, "", fmt.Sprintf("%s-%s", ns.Name, name))
+		server.SetLabels(serverLabels)
+		Expect(k8sClient.Create(ctx, server)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, server)
+
+		Expect(unstructured.SetNestedMap(server.Object, map[string]any{
+			"totalSystemMemory": memory,
+			"processors": []any{
+				map[string]any{"architecture": "x86_64", "cores": threads / 2, "threads": threads},
+			},
+		}, "status")).To(Succeed())
+		Expect(k8sClient.Status().Update(ctx, server)).To(Succeed())
+	}
+
+	It("should use the NodeTemplate of the worker pool", func(ctx SpecContext) {
+		pool.NodeTemplate = &gardenerextensionv1alpha1.NodeTemplate{
+			Capacity: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+		}
+		createServer(ctx, "server", "64Gi", 16)
+
+		Expect(delegate.generateNodeTemplate(ctx, pool, "zone1", labels.SelectorFromSet(serverLabels))).To(Equal(&machinecontrollerv1alpha1.NodeTemplate{
+			Capacity:     pool.NodeTemplate.Capacity,
+			InstanceType: "large",
+			Region:       "foo",
+			Zone:         "zone1",
+		}))
+	})
+
+	It("should derive the smallest capacity of the matching servers", func(ctx SpecContext) {
+		createServer(ctx, "small", "64Gi", 16)
+		createServer(ctx, "big", "128Gi", 32)
+
+		Expect(delegate.generateNodeTemplate(ctx, pool, "zone1", labels.SelectorFromSet(serverLabels))).To(Equal(&machinecontrollerv1alpha1.NodeTemplate{
+			Capacity: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("16"),
+				corev1.ResourceMemory: resource.MustParse("64Gi"),
+			},
+			InstanceType: "large",
+			Region:       "foo",
+			Zone:         "zone1",
+			Architecture: ptr.To("amd64"),
+		}))
+	})
+
+	It("should fall back to the machine type of the CloudProfile", func(ctx SpecContext) {
+		Expect(delegate.generateNodeTemplate(ctx, pool, "zone1", labels.SelectorFromSet(serverLabels))).To(Equal(&machinecontrollerv1alpha1.NodeTemplate{
+			Capacity: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("8"),
+				corev1.ResourceMemory: resource.MustParse("32Gi"),
+			},
+			InstanceType: "large",
+			Region:       "foo",
+			Zone:         "zone1",
+			Architecture: ptr.To("arm64"),
+		}))
+	})
+})
@@ -0,0 +1,98 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package worker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gardener/gardener/extensions/pkg/controller/worker"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	v1beta1helper "github.com/gardener/gardener/pkg/apis/core/v1beta1/helper"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/metal"
+)
+
+// checkServerCapacity compares the maximum number of machines of every worker pool zone with the number of servers
+// matching the server labels of the zone. It returns a message for every zone which cannot be scaled to its maximum.
+// The cluster scoped Servers are read with the reader returned by getServerReader, the ServerClaims of the cluster with
+// the credentials of the worker.
+func (w *workerDelegate) checkServerCapacity(ctx context.Context) ([]string, error) {
+	metalClient, namespace, err := w.getMetalClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, err := metal.ListClusterServerClaims(ctx, metalClient, namespace, w.cluster.ObjectMeta.Name)
+	if err != nil {
+		return nil, err
+	}
+	serverReader, err := w.getServerReader(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var shortages []string
+	for _, pool := range w.worker.Spec.Pools {
+		workerConfig, err := decodeWorkerConfig(w.decoder, pool)
+		if err != nil {
+			return nil, err
+		}
+
+		zoneLen := int32(len(pool.Zones))
+		for zoneIndex, zone := range pool.Zones {
+			serverSelector, err := w.getServerSelectorForMachine(pool.MachineType, zone, workerConfig)
+			if err != nil {
+				return nil, err
+			}
+			selector, err := metav1.LabelSelectorAsSelector(serverSelector)
+			if err != nil {
+				return nil, fmt.Errorf("invalid server selector for pool %s: %w", pool.Name, err)
+			}
+
+			available, err := metal.CountAvailableServers(ctx, serverReader, selector, claims)
+			if err != nil {
+				return nil, err
+			}
+
+			if maximum := worker.DistributeOverZones(int32(zoneIndex), pool.Maximum, zoneLen); int32(available) < maximum {
+				shortages = append(shortages, fmt.Sprintf("pool %s can scale up to %d machines in zone %s, but only %d matching servers are available", pool.Name, maximum, zone, available))
+			}
+		}
+	}
+
+	return shortages, nil
+}
+
+// updateServerCapacityCondition reports the result of the server capacity check as condition of the worker. The
+// check does not block the reconciliation, as machines are created as soon as matching servers become available.
+func (w *workerDelegate) updateServerCapacityCondition(ctx context.Context) error {
+	var (
+		clk       = clock.RealClock{}
+		condition = v1beta1helper.GetOrInitConditionWithClock(clk, w.worker.Status.Conditions, metal.ConditionTypeServerCapacityAvailable)
+	)
+
+	shortages, err := w.checkServerCapacity(ctx)
+	switch {
+	case err != nil:
+		condition = v1beta1helper.UpdatedConditionWithClock(clk, condition, gardencorev1beta1.ConditionUnknown, metal.ReasonServerCapacityUnknown, err.Error())
+	case len(shortages) > 0:
+		condition = v1beta1helper.UpdatedConditionWithClock(clk, condition, gardencorev1beta1.ConditionFalse, metal.ReasonServerCapacityInsufficient,
+			strings.Join(shortages, "; "), gardencorev1beta1.ErrorInfraResourcesDepleted)
+	default:
+		condition = v1beta1helper.UpdatedConditionWithClock(clk, condition, gardencorev1beta1.ConditionTrue, metal.ReasonServerCapacitySufficient,
+			"Enough matching servers are available for the maximum of all worker pools")
+	}
+
+	patch := client.MergeFrom(w.worker.DeepCopy())
+	w.worker.Status.Conditions = v1beta1helper.MergeConditions(w.worker.Status.Conditions, condition)
+	if err := w.client.Status().Patch(ctx, w.worker, patch); err != nil {
+		return fmt.Errorf("failed to patch worker status: %w", err)
+	}
+	return nil
+}
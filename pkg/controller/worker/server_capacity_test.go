@@ -0,0 +1,118 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package worker
+
+import (
+	"fmt"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	. "sigs.k8s.io/controller-runtime/pkg/envtest/komega"
+
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/metal"
+)
+
+var _ = Describe("Server capacity", func() {
+	ns, _ := SetupTest()
+
+	var (
+		workerDelegate *workerDelegate
+		metalNamespace *corev1.Namespace
+	)
+
+	BeforeEach(func(ctx SpecContext) {
+		metalNamespace = SetupMetalNamespace(ctx, ns)
+		Expect(k8sClient.Create(ctx, w)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, w)
+
+		workerDelegate = NewTestWorkerDelegate()
+	})
+
+	createServers := func(ctx SpecContext, count int) {
+		for i := range count {
+			server := metal.NewUnstructured(metal.ServerGVK, "", fmt.Sprintf("%s-server-%d", ns.Name, i))
+			server.SetLabels(map[string]string{"foo": "bar", "foo1": "bar1", "rack": "rack1"})
+			Expect(k8sClient.Create(ctx, server)).To(Succeed())
+			DeferCleanup(k8sClient.Delete, server)
+
+			Expect(unstructured.SetNestedField(server.Object, metal.ServerStateAvailable, "status", "state")).To(Succeed())
+			Expect(k8sClient.Status().Update(ctx, server)).To(Succeed())
+		}
+	}
+
+	It("should report sufficient capacity if enough servers match every zone", func(ctx SpecContext) {
+		createServers(ctx, 5)
+
+		Expect(workerDelegate.PreReconcileHook(ctx)).To(Succeed())
+
+		Eventually(Object(w)).Should(HaveField("Status.Conditions", ConsistOf(SatisfyAll(
+			HaveField("Type", gardencorev1beta1.ConditionType(metal.ConditionTypeServerCapacityAvailable)),
+			HaveField("Status", gardencorev1beta1.ConditionTrue),
+			HaveField("Reason", metal.ReasonServerCapacitySufficient),
+		))))
+	})
+
+	It("should report the zones which cannot be scaled to their maximum", func(ctx SpecContext) {
+		createServers(ctx, 3)
+
+		Expect(workerDelegate.PreReconcileHook(ctx)).To(Succeed())
+
+		Eventually(Object(w)).Should(HaveField("Status.Conditions", ConsistOf(SatisfyAll(
+			HaveField("Type", gardencorev1beta1.ConditionType(metal.ConditionTypeServerCapacityAvailable)),
+			HaveField("Status", gardencorev1beta1.ConditionFalse),
+			HaveField("Reason", metal.ReasonServerCapacityInsufficient),
+			HaveField("Message", SatisfyAll(
+				ContainSubstring("pool pool can scale up to 5 machines in zone zone1, but only 3 matching servers are available"),
+				ContainSubstring("zone zone2"),
+			)),
+			HaveField("Codes", ConsistOf(gardencorev1beta1.ErrorInfraResourcesDepleted)),
+		))))
+	})
+
+	When("the credentials of the worker are refused on servers", func() {
+		BeforeEach(func(ctx SpecContext) {
+			tenantKubeconfig := AddTestUser(ctx, ns.Name+"-tenant", metalNamespace.Name, rbacv1.PolicyRule{
+				APIGroups: []string{metal.ServerClaimGVK.Group},
+				Resources: []string{"serverclaims"},
+				Verbs:     []string{"get", "list"},
+			})
+
+			secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: ns.Name, Name: w.Spec.SecretRef.Name}}
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(secret), secret)).To(Succeed())
+			secret.Data[metal.KubeConfigFieldName] = tenantKubeconfig
+			Expect(k8sClient.Update(ctx, secret)).To(Succeed())
+		})
+
+		It("should read the servers with the admin credentials of the region", func(ctx SpecContext) {
+			SetRegionAdminKubeconfig(ctx, ns, workerDelegate, kubeconfig)
+			createServers(ctx, 5)
+
+			Expect(workerDelegate.PreReconcileHook(ctx)).To(Succeed())
+
+			Eventually(Object(w)).Should(HaveField("Status.Conditions", ConsistOf(SatisfyAll(
+				HaveField("Type", gardencorev1beta1.ConditionType(metal.ConditionTypeServerCapacityAvailable)),
+				HaveField("Status", gardencorev1beta1.ConditionTrue),
+				HaveField("Reason", metal.ReasonServerCapacitySufficient),
+			))))
+		})
+
+		It("should report an unknown capacity without admin credentials of the region", func(ctx SpecContext) {
+			createServers(ctx, 5)
+
+			Expect(workerDelegate.PreReconcileHook(ctx)).To(Succeed())
+
+			Eventually(Object(w)).Should(HaveField("Status.Conditions", ConsistOf(SatisfyAll(
+				HaveField("Type", gardencorev1beta1.ConditionType(metal.ConditionTypeServerCapacityAvailable)),
+				HaveField("Status", gardencorev1beta1.ConditionUnknown),
+				HaveField("Reason", metal.ReasonServerCapacityUnknown),
+			))))
+		})
+	})
+})
@@ -0,0 +1,261 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package worker
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	v1beta1helper "github.com/gardener/gardener/pkg/apis/core/v1beta1/helper"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	metalv1alpha1 "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal/v1alpha1"
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/metal"
+)
+
+// wipingPools returns the names of the worker pools which wipe their Servers when they are released.
+func (w *workerDelegate) wipingPools() (sets.Set[string], error) {
+	pools := sets.New[string]()
+	for _, pool := range w.worker.Spec.Pools {
+		workerConfig, err := decodeWorkerConfig(w.decoder, pool)
+		if err != nil {
+			return nil, err
+		}
+		if workerConfig.ReleasePolicy != nil && *workerConfig.ReleasePolicy == metalv1alpha1.ReleasePolicyWipe {
+			pools.Insert(pool.Name)
+		}
+	}
+	return pools, nil
+}
+
+// trackWipedServers adds the Servers currently claimed by the machines of worker pools with the Wipe release policy
+// to the Servers tracked in the provider status of the worker. Worker pools which had the Wipe release policy when the
+// Servers were last tracked are considered as well, so that Servers claimed since then by a worker pool which has been
+// removed meanwhile are not missed. It returns the tracked Servers and the names of the Servers which are currently
+// claimed by the cluster.
+func (w *workerDelegate) trackWipedServers(ctx context.Context) ([]metalv1alpha1.WipedServer, sets.Set[string], error) {
+	workerStatus, err := w.decodeWorkerProviderStatus()
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to decode the worker provider status: %w", err)
+	}
+	currentWipingPools, err := w.wipingPools()
+	if err != nil {
+		return nil, nil, err
+	}
+	wipingPools := currentWipingPools.Union(sets.New(workerStatus.WipingPools...))
+	if len(workerStatus.WipedServers) == 0 && wipingPools.Len() == 0 {
+		return nil, nil, nil
+	}
+
+	metalClient, namespace, err := w.getMetalClient(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	claimedServers, err := metal.ListClusterClaimedServers(ctx, metalClient, namespace, w.cluster.ObjectMeta.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tracked := slices.Clone(workerStatus.WipedServers)
+	for _, serverName := range sets.List(sets.KeySet(claimedServers)) {
+		pool := claimedServers[serverName]
+		// ServerClaims created before the worker pool label was introduced are attributed to no pool, their Servers are
+		// only waited for when the whole worker is deleted.
+		if (pool == "" && wipingPools.Len() == 0) || (pool != "" && !wipingPools.Has(pool)) {
+			continue
+		}
+		if !slices.ContainsFunc(tracked, func(s metalv1alpha1.WipedServer) bool { return s.Name == serverName }) {
+			tracked = append(tracked, metalv1alpha1.WipedServer{Name: serverName, Pool: pool})
+		}
+	}
+
+	if !slices.Equal(tracked, workerStatus.WipedServers) || !slices.Equal(sets.List(currentWipingPools), workerStatus.WipingPools) {
+		workerStatus.WipedServers = tracked
+		workerStatus.WipingPools = sets.List(currentWipingPools)
+		if err := w.updateWorkerProviderStatus(ctx, workerStatus); err != nil {
+			return nil, nil, fmt.Errorf("failed to update worker provider status: %w", err)
+		}
+	}
+	return tracked, sets.KeySet(claimedServers), nil
+}
+
+// recordWipedServers records the Servers currently claimed by the machines of worker pools with the Wipe release
+// policy in the provider status of the worker, so that they can be waited for once they are released.
+func (w *workerDelegate) recordWipedServers(ctx context.Context) error {
+	_, _, err := w.trackWipedServers(ctx)
+	return err
+}
+
+// waitForServersSanitized returns an error as long as tracked Servers released by the machines of the cluster have not
+// been sanitized yet and are selected by the given function, so that no other tenant can inherit data from their disks.
+// The result is reported as ServersSanitized condition of the worker. If the Servers cannot be read, the condition
+// reports the reason and an error is returned, unless the extension is configured to skip unverifiable sanitizations.
+func (w *workerDelegate) waitForServersSanitized(ctx context.Context, waitFor func(metalv1alpha1.WipedServer) bool) error {
+	tracked, claimed, err := w.trackWipedServers(ctx)
+	if err != nil || len(tracked) == 0 {
+		return err
+	}
+
+	_, namespace, err := w.getMetalClient(ctx)
+	if err != nil {
+		return err
+	}
+	serverReader, err := w.getServerReader(ctx)
+	if err != nil {
+		return err
+	}
+
+	var (
+		stillTracked []metalv1alpha1.WipedServer
+		pending      []string
+		waiting      []string
+	)
+	for _, server := range tracked {
+		if claimed.Has(server.Name) {
+			stillTracked = append(stillTracked, server)
+			continue
+		}
+
+		obj := metal.NewUnstructured(metal.ServerGVK, "", server.Name)
+		if err := serverReader.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			if apierrors.IsForbidden(err) || meta.IsNoMatchError(err) {
+				return w.handleUnverifiableServerSanitization(ctx, stillTrackedClaimed(tracked, claimed), err)
+			}
+			return fmt.Errorf("failed to get Server %s: %w", server.Name, err)
+		}
+		if metal.IsServerSanitized(obj, namespace) {
+			continue
+		}
+
+		stillTracked = append(stillTracked, server)
+		pending = append(pending, server.Name)
+		if waitFor(server) {
+			waiting = append(waiting, server.Name)
+		}
+	}
+
+	if err := w.updateWipedServers(ctx, stillTracked); err != nil {
+		return err
+	}
+
+	slices.Sort(pending)
+	if len(pending) > 0 {
+		message := fmt.Sprintf("Servers %s released by the cluster are being sanitized", strings.Join(pending, ", "))
+		if err := w.updateServersSanitizedCondition(ctx, gardencorev1beta1.ConditionFalse, metal.ReasonServerSanitizationPending, message); err != nil {
+			return err
+		}
+	} else if err := w.updateServersSanitizedCondition(ctx, gardencorev1beta1.ConditionTrue, metal.ReasonServersSanitized, "All Servers released by the cluster have been sanitized"); err != nil {
+		return err
+	}
+
+	if len(waiting) > 0 {
+		slices.Sort(waiting)
+		return fmt.Errorf("waiting for Servers %s to be sanitized", strings.Join(waiting, ", "))
+	}
+	return nil
+}
+
+// waitForServerClaimsReleased returns an error as long as ServerClaims of the cluster exist when a worker pool wipes its
+// Servers on release, so that the Worker is only deleted once all Servers have been released.
+func (w *workerDelegate) waitForServerClaimsReleased(ctx context.Context) error {
+	wipingPools, err := w.wipingPools()
+	if err != nil || wipingPools.Len() == 0 {
+		return err
+	}
+
+	metalClient, namespace, err := w.getMetalClient(ctx)
+	if err != nil {
+		return err
+	}
+	claims, err := metal.ListClusterServerClaims(ctx, metalClient, namespace, w.cluster.ObjectMeta.Name)
+	if err != nil {
+		return err
+	}
+	if claims.Len() > 0 {
+		return fmt.Errorf("waiting for %d ServerClaims of the cluster to be released", claims.Len())
+	}
+	return nil
+}
+
+// handleUnverifiableServerSanitization reports that the sanitization of the released Servers cannot be verified,
+// because the Servers cannot be read. Unless the extension is configured to skip unverifiable sanitizations, an error
+// is returned and the released Servers stay tracked. Otherwise, only the Servers still claimed by the cluster stay
+// tracked.
+func (w *workerDelegate) handleUnverifiableServerSanitization(ctx context.Context, claimedServers []metalv1alpha1.WipedServer, reason error) error {
+	if err := w.updateServersSanitizedCondition(ctx, gardencorev1beta1.ConditionUnknown, metal.ReasonServerSanitizationUnknown,
+		fmt.Sprintf("Cannot verify that the Servers released by the cluster have been sanitized: %s", reason.Error())); err != nil {
+		return err
+	}
+	if !w.serverSanitization.SkipUnverifiable {
+		return fmt.Errorf("failed to verify that the released Servers have been sanitized: %w", reason)
+	}
+	return w.updateWipedServers(ctx, claimedServers)
+}
+
+// stillTrackedClaimed returns the tracked Servers which are still claimed by the cluster.
+func stillTrackedClaimed(tracked []metalv1alpha1.WipedServer, claimed sets.Set[string]) []metalv1alpha1.WipedServer {
+	var servers []metalv1alpha1.WipedServer
+	for _, server := range tracked {
+		if claimed.Has(server.Name) {
+			servers = append(servers, server)
+		}
+	}
+	return servers
+}
+
+// updateWipedServers stores the given Servers as the Servers tracked in the provider status of the worker.
+func (w *workerDelegate) updateWipedServers(ctx context.Context, servers []metalv1alpha1.WipedServer) error {
+	workerStatus, err := w.decodeWorkerProviderStatus()
+	if err != nil {
+		return fmt.Errorf("unable to decode the worker provider status: %w", err)
+	}
+	if slices.Equal(servers, workerStatus.WipedServers) {
+		return nil
+	}
+	workerStatus.WipedServers = servers
+	if err := w.updateWorkerProviderStatus(ctx, workerStatus); err != nil {
+		return fmt.Errorf("failed to update worker provider status: %w", err)
+	}
+	return nil
+}
+
+// updateServersSanitizedCondition reports the sanitization of the released Servers as condition of the worker.
+func (w *workerDelegate) updateServersSanitizedCondition(ctx context.Context, status gardencorev1beta1.ConditionStatus, reason, message string) error {
+	var (
+		clk       = clock.RealClock{}
+		condition = v1beta1helper.GetOrInitConditionWithClock(clk, w.worker.Status.Conditions, metal.ConditionTypeServersSanitized)
+	)
+	condition = v1beta1helper.UpdatedConditionWithClock(clk, condition, status, reason, message)
+
+	patch := client.MergeFrom(w.worker.DeepCopy())
+	w.worker.Status.Conditions = v1beta1helper.MergeConditions(w.worker.Status.Conditions, condition)
+	if err := w.client.Status().Patch(ctx, w.worker, patch); err != nil {
+		return fmt.Errorf("failed to patch worker status: %w", err)
+	}
+	return nil
+}
+
+// removedPool returns whether the given Server was claimed by a machine of a worker pool which has been removed from
+// the worker.
+func (w *workerDelegate) removedPool(server metalv1alpha1.WipedServer) bool {
+	return server.Pool != "" && !slices.ContainsFunc(w.worker.Spec.Pools, func(pool extensionsv1alpha1.WorkerPool) bool {
+		return pool.Name == server.Pool
+	})
+}
+
+// allServers selects all Servers, it is used when the worker is deleted.
+func allServers(metalv1alpha1.WipedServer) bool {
+	return true
+}
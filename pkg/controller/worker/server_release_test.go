@@ -0,0 +1,163 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package worker
+
+import (
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	. "sigs.k8s.io/controller-runtime/pkg/envtest/komega"
+
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/config"
+	apiv1alpha1 "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal/v1alpha1"
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/metal"
+)
+
+var _ = Describe("Server release", func() {
+	ns, _ := SetupTest()
+
+	var (
+		workerDelegate *workerDelegate
+		metalNamespace *corev1.Namespace
+	)
+
+	BeforeEach(func(ctx SpecContext) {
+		metalNamespace = SetupMetalNamespace(ctx, ns)
+		Expect(k8sClient.Create(ctx, w)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, w)
+
+		workerDelegate = NewTestWorkerDelegate()
+	})
+
+	createClaimedServer := func(ctx SpecContext) (*unstructured.Unstructured, *unstructured.Unstructured) {
+		server := metal.NewUnstructured(metal.ServerGVK, "", ns.Name+"-server")
+		Expect(k8sClient.Create(ctx, server)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, server)
+
+		Expect(unstructured.SetNestedField(server.Object, metal.ServerStateReserved, "status", "state")).To(Succeed())
+		Expect(k8sClient.Status().Update(ctx, server)).To(Succeed())
+
+		claim := metal.NewUnstructured(metal.ServerClaimGVK, metalNamespace.Name, "claim")
+		claim.SetLabels(map[string]string{
+			metal.ClusterNameLabel: testCluster.ObjectMeta.Name,
+			metal.WorkerPoolLabel:  pool.Name,
+		})
+		Expect(unstructured.SetNestedField(claim.Object, server.GetName(), "spec", "serverRef", "name")).To(Succeed())
+		Expect(k8sClient.Create(ctx, claim)).To(Succeed())
+		return server, claim
+	}
+
+	releaseServer := func(ctx SpecContext, server, claim *unstructured.Unstructured) {
+		Expect(k8sClient.Delete(ctx, claim)).To(Succeed())
+		Expect(unstructured.SetNestedField(server.Object, metal.ServerStateTainted, "status", "state")).To(Succeed())
+		Expect(k8sClient.Status().Update(ctx, server)).To(Succeed())
+	}
+
+	It("should wait until the released servers of the cluster have been sanitized", func(ctx SpecContext) {
+		server, claim := createClaimedServer(ctx)
+
+		Expect(workerDelegate.PreDeleteHook(ctx)).To(Succeed())
+		releaseServer(ctx, server, claim)
+
+		Expect(workerDelegate.PostDeleteHook(ctx)).To(MatchError(ContainSubstring("waiting for Servers " + server.GetName() + " to be sanitized")))
+		Eventually(Object(w)).Should(HaveField("Status.Conditions", ContainElement(SatisfyAll(
+			HaveField("Type", gardencorev1beta1.ConditionType(metal.ConditionTypeServersSanitized)),
+			HaveField("Status", gardencorev1beta1.ConditionFalse),
+			HaveField("Reason", metal.ReasonServerSanitizationPending),
+		))))
+
+		Expect(unstructured.SetNestedField(server.Object, metal.ServerStateAvailable, "status", "state")).To(Succeed())
+		Expect(k8sClient.Status().Update(ctx, server)).To(Succeed())
+
+		Expect(workerDelegate.PostDeleteHook(ctx)).To(Succeed())
+		Eventually(Object(w)).Should(HaveField("Status.Conditions", ContainElement(SatisfyAll(
+			HaveField("Type", gardencorev1beta1.ConditionType(metal.ConditionTypeServersSanitized)),
+			HaveField("Status", gardencorev1beta1.ConditionTrue),
+			HaveField("Reason", metal.ReasonServersSanitized),
+		))))
+	})
+
+	It("should wait for the released servers of a removed worker pool on reconcile", func(ctx SpecContext) {
+		server, claim := createClaimedServer(ctx)
+
+		Expect(workerDelegate.PostReconcileHook(ctx)).To(Succeed())
+		releaseServer(ctx, server, claim)
+
+		By("not blocking the reconciliation while the worker pool still exists")
+		Expect(workerDelegate.PostReconcileHook(ctx)).To(Succeed())
+
+		By("blocking the reconciliation once the worker pool has been removed")
+		workerDelegate.worker.Spec.Pools = nil
+		Expect(workerDelegate.PostReconcileHook(ctx)).To(MatchError(ContainSubstring("waiting for Servers " + server.GetName() + " to be sanitized")))
+	})
+
+	It("should track the servers claimed by a worker pool which has been removed before the next reconciliation", func(ctx SpecContext) {
+		Expect(workerDelegate.PostReconcileHook(ctx)).To(Succeed())
+
+		By("claiming a server between two reconciliations and removing the worker pool")
+		server, claim := createClaimedServer(ctx)
+		workerDelegate.worker.Spec.Pools = nil
+
+		Expect(workerDelegate.PreReconcileHook(ctx)).To(Succeed())
+		workerStatus, err := workerDelegate.decodeWorkerProviderStatus()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(workerStatus.WipedServers).To(ConsistOf(apiv1alpha1.WipedServer{Name: server.GetName(), Pool: pool.Name}))
+		Expect(workerStatus.WipingPools).To(BeEmpty())
+
+		releaseServer(ctx, server, claim)
+		Expect(workerDelegate.PostReconcileHook(ctx)).To(MatchError(ContainSubstring("waiting for Servers " + server.GetName() + " to be sanitized")))
+	})
+
+	When("the servers cannot be read", func() {
+		var server *unstructured.Unstructured
+
+		BeforeEach(func(ctx SpecContext) {
+			SetRegionAdminKubeconfig(ctx, ns, workerDelegate, AddTestUser(ctx, ns.Name+"-restricted", ""))
+
+			var claim *unstructured.Unstructured
+			server, claim = createClaimedServer(ctx)
+			Expect(workerDelegate.PreDeleteHook(ctx)).To(Succeed())
+			releaseServer(ctx, server, claim)
+		})
+
+		It("should fail and keep tracking the released servers", func(ctx SpecContext) {
+			Expect(workerDelegate.PostDeleteHook(ctx)).To(MatchError(ContainSubstring("failed to verify that the released Servers have been sanitized")))
+			Eventually(Object(w)).Should(HaveField("Status.Conditions", ContainElement(SatisfyAll(
+				HaveField("Type", gardencorev1beta1.ConditionType(metal.ConditionTypeServersSanitized)),
+				HaveField("Status", gardencorev1beta1.ConditionUnknown),
+				HaveField("Reason", metal.ReasonServerSanitizationUnknown),
+			))))
+
+			workerStatus, err := workerDelegate.decodeWorkerProviderStatus()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(workerStatus.WipedServers).To(ConsistOf(HaveField("Name", server.GetName())))
+		})
+
+		It("should stop tracking the released servers if configured to skip unverifiable sanitizations", func(ctx SpecContext) {
+			workerDelegate.serverSanitization = config.ServerSanitization{SkipUnverifiable: true}
+
+			Expect(workerDelegate.PostDeleteHook(ctx)).To(Succeed())
+			Eventually(Object(w)).Should(HaveField("Status.Conditions", ContainElement(SatisfyAll(
+				HaveField("Type", gardencorev1beta1.ConditionType(metal.ConditionTypeServersSanitized)),
+				HaveField("Status", gardencorev1beta1.ConditionUnknown),
+				HaveField("Reason", metal.ReasonServerSanitizationUnknown),
+			))))
+
+			workerStatus, err := workerDelegate.decodeWorkerProviderStatus()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(workerStatus.WipedServers).To(BeEmpty())
+		})
+	})
+
+	It("should wait until the server claims of the cluster have been released", func(ctx SpecContext) {
+		claim := metal.NewUnstructured(metal.ServerClaimGVK, metalNamespace.Name, "claim")
+		claim.SetLabels(map[string]string{metal.ClusterNameLabel: testCluster.ObjectMeta.Name})
+		Expect(k8sClient.Create(ctx, claim)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, claim)
+
+		Expect(workerDelegate.PostDeleteHook(ctx)).To(MatchError(ContainSubstring("waiting for 1 ServerClaims of the cluster to be released")))
+	})
+})
@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package worker
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/utils/ptr"
+
+	metalv1alpha1 "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal/v1alpha1"
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/ignition"
+)
+
+// storageIgnition returns the Ignition config creating the disk layout of the given StorageConfig. Filesystems with a
+// mount point are mounted by a systemd mount unit before local-fs.target is reached.
+func storageIgnition(storage *metalv1alpha1.StorageConfig) *ignition.Spec {
+	if storage == nil {
+		return nil
+	}
+
+	cfg := &ignition.Spec{Storage: &ignition.Storage{}}
+	for _, disk := range storage.Disks {
+		ignitionDisk := ignition.Disk{
+			Device:    disk.Device,
+			WipeTable: disk.WipeTable,
+		}
+		for _, partition := range disk.Partitions {
+			ignitionPartition := ignition.Partition{Label: ptr.To(partition.Label)}
+			if partition.Size != nil {
+				ignitionPartition.SizeMiB = ptr.To(int(partition.Size.Value() >> 20))
+			}
+			ignitionDisk.Partitions = append(ignitionDisk.Partitions, ignitionPartition)
+		}
+		cfg.Storage.Disks = append(cfg.Storage.Disks, ignitionDisk)
+	}
+
+	for _, raid := range storage.RAIDs {
+		ignitionRaid := ignition.Raid{
+			Name:    raid.Name,
+			Level:   ptr.To(string(raid.Level)),
+			Devices: raid.Devices,
+		}
+		if raid.Spares != nil {
+			ignitionRaid.Spares = ptr.To(int(*raid.Spares))
+		}
+		cfg.Storage.Raid = append(cfg.Storage.Raid, ignitionRaid)
+	}
+
+	for _, filesystem := range storage.Filesystems {
+		cfg.Storage.Filesystems = append(cfg.Storage.Filesystems, ignition.Filesystem{
+			Device:         filesystem.Device,
+			Format:         ptr.To(string(filesystem.Format)),
+			Label:          filesystem.Label,
+			MountOptions:   filesystem.MountOptions,
+			Path:           filesystem.MountPoint,
+			WipeFilesystem: filesystem.WipeFilesystem,
+		})
+
+		if filesystem.MountPoint == nil {
+			continue
+		}
+		if cfg.Systemd == nil {
+			cfg.Systemd = &ignition.Systemd{}
+		}
+		cfg.Systemd.Units = append(cfg.Systemd.Units, ignition.Unit{
+			Name:     mountUnitName(*filesystem.MountPoint),
+			Enabled:  ptr.To(true),
+			Contents: ptr.To(mountUnitContents(filesystem)),
+		})
+	}
+
+	return cfg
+}
+
+// mountUnitName returns the name of the systemd mount unit of the given mount point, e.g. var-lib-containerd.mount
+// for /var/lib/containerd.
+func mountUnitName(mountPoint string) string {
+	path := strings.Trim(mountPoint, "/")
+	if path == "" {
+		return "-.mount"
+	}
+
+	var name strings.Builder
+	for i := 0; i < len(path); i++ {
+		switch c := path[i]; {
+		case c == '/':
+			name.WriteByte('-')
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '_', c == '.' && i > 0:
+			name.WriteByte(c)
+		default:
+			fmt.Fprintf(&name, `\x%02x`, c)
+		}
+	}
+	return name.String() + ".mount"
+}
+
+func mountUnitContents(filesystem metalv1alpha1.FilesystemConfig) string {
+	var contents strings.Builder
+	fmt.Fprintf(&contents, "[Unit]\nDescription=Mount %s at %s\nBefore=local-fs.target\n\n", filesystem.Device, *filesystem.MountPoint)
+	fmt.Fprintf(&contents, "[Mount]\nWhat=%s\nWhere=%s\nType=%s\n", filesystem.Device, *filesystem.MountPoint, filesystem.Format)
+	if len(filesystem.MountOptions) > 0 {
+		fmt.Fprintf(&contents, "Options=%s\n", strings.Join(filesystem.MountOptions, ","))
+	}
+	contents.WriteString("\n[Install]\nRequiredBy=local-fs.target\n")
+	return contents.String()
+}
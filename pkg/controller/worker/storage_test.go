@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package worker
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+
+	apiv1alpha1 "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal/v1alpha1"
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/ignition"
+)
+
+var _ = Describe("Storage", func() {
+	Describe("#storageIgnition", func() {
+		It("should return nil without storage layout", func() {
+			Expect(storageIgnition(nil)).To(BeNil())
+		})
+
+		It("should generate the storage section and mount units", func() {
+			cfg := storageIgnition(&apiv1alpha1.StorageConfig{
+				Disks: []apiv1alpha1.DiskConfig{
+					{Device: "/dev/nvme0n1", WipeTable: ptr.To(true), Partitions: []apiv1alpha1.PartitionConfig{
+						{Label: "containerd-0", Size: ptr.To(resource.MustParse("200Gi"))},
+						{Label: "kubelet"},
+					}},
+				},
+				RAIDs: []apiv1alpha1.RAIDConfig{
+					{Name: "containerd", Level: apiv1alpha1.RAIDLevel1, Devices: []string{"/dev/disk/by-partlabel/containerd-0", "/dev/sdb"}, Spares: ptr.To[int32](1)},
+				},
+				Filesystems: []apiv1alpha1.FilesystemConfig{
+					{Device: "/dev/md/containerd", Format: apiv1alpha1.FilesystemFormatXFS, MountPoint: ptr.To("/var/lib/containerd"), MountOptions: []string{"noatime", "nodiscard"}},
+					{Device: "/dev/disk/by-partlabel/kubelet", Format: apiv1alpha1.FilesystemFormatExt4},
+				},
+			})
+
+			Expect(cfg.Storage).To(Equal(&ignition.Storage{
+				Disks: []ignition.Disk{
+					{Device: "/dev/nvme0n1", WipeTable: ptr.To(true), Partitions: []ignition.Partition{
+						{Label: ptr.To("containerd-0"), SizeMiB: ptr.To(204800)},
+						{Label: ptr.To("kubelet")},
+					}},
+				},
+				Raid: []ignition.Raid{
+					{Name: "containerd", Level: ptr.To("raid1"), Devices: []string{"/dev/disk/by-partlabel/containerd-0", "/dev/sdb"}, Spares: ptr.To(1)},
+				},
+				Filesystems: []ignition.Filesystem{
+					{Device: "/dev/md/containerd", Format: ptr.To("xfs"), Path: ptr.To("/var/lib/containerd"), MountOptions: []string{"noatime", "nodiscard"}},
+					{Device: "/dev/disk/by-partlabel/kubelet", Format: ptr.To("ext4")},
+				},
+			}))
+			Expect(cfg.Systemd.Units).To(Equal([]ignition.Unit{{
+				Name:    "var-lib-containerd.mount",
+				Enabled: ptr.To(true),
+				Contents: ptr.To(`[Unit]
+Description=Mount /dev/md/containerd at /var/lib/containerd
+Before=local-fs.target
+
+[Mount]
+What=/dev/md/containerd
+Where=/var/lib/containerd
+Type=xfs
+Options=noatime,nodiscard
+
+[Install]
+RequiredBy=local-fs.target
+`),
+			}}))
+		})
+	})
+
+	DescribeTable("#mountUnitName",
+		func(mountPoint, name string) {
+			Expect(mountUnitName(mountPoint)).To(Equal(name))
+		},
+		Entry("root", "/", "-.mount"),
+		Entry("nested path", "/var/lib/containerd", "var-lib-containerd.mount"),
+		Entry("path with dashes", "/var/lib/my-data", `var-lib-my\x2ddata.mount`),
+		Entry("path with leading dot", "/.hidden", `\x2ehidden.mount`),
+	)
+})
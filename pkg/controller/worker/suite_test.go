@@ -21,10 +21,12 @@ import (
 	. "github.com/onsi/gomega"
 	"go.uber.org/zap/zapcore"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	apiextensionsscheme "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/scheme"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	apiruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
@@ -36,7 +38,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/yaml"
 
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/config"
 	apiv1alpha1 "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal/v1alpha1"
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/metal"
 )
 
 const (
@@ -46,9 +50,10 @@ const (
 )
 
 var (
-	testEnv   *envtest.Environment
-	cfg       *rest.Config
-	k8sClient client.Client
+	testEnv    *envtest.Environment
+	cfg        *rest.Config
+	k8sClient  client.Client
+	kubeconfig []byte
 )
 
 // global Gardener resources used by delegates
@@ -93,6 +98,7 @@ var _ = BeforeSuite(func() {
 			modutils.Dir("github.com/gardener/machine-controller-manager", "kubernetes", "crds", "machine.sapcloud.io_machinesets.yaml"),
 			filepath.Join("..", "..", "..", "example", "20-crd-extensions.gardener.cloud_controlplanes.yaml"),
 			filepath.Join("..", "..", "..", "example", "20-crd-extensions.gardener.cloud_workers.yaml"),
+			filepath.Join("..", "..", "..", "test", "crds"),
 		},
 		ErrorIfCRDPathMissing: true,
 
@@ -122,6 +128,15 @@ var _ = BeforeSuite(func() {
 	Expect(k8sClient).NotTo(BeNil())
 
 	komega.SetClient(k8sClient)
+
+	user, err := testEnv.AddUser(envtest.User{
+		Name:   "dummy",
+		Groups: []string{"system:authenticated", "system:masters"},
+	}, cfg)
+	Expect(err).NotTo(HaveOccurred())
+
+	kubeconfig, err = user.KubeConfig()
+	Expect(err).NotTo(HaveOccurred())
 })
 
 func SetupTest() (*corev1.Namespace, *gardener.ChartApplier) {
@@ -177,6 +192,11 @@ func SetupTest() (*corev1.Namespace, *gardener.ChartApplier) {
 			ExtraServerLabels: map[string]string{
 				"foo1": "bar1",
 			},
+			ServerSelector: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "maintenance", Operator: metav1.LabelSelectorOpDoesNotExist},
+				},
+			},
 
 			ExtraIgnition: &apiv1alpha1.IgnitionConfig{
 				Raw: yamlString,
@@ -186,9 +206,19 @@ func SetupTest() (*corev1.Namespace, *gardener.ChartApplier) {
 				Override: true,
 			},
 			Metadata: map[string]string{
-				"foo": "bar",
-				"baz": "100",
+				"foo":  "bar",
+				"baz":  "100",
+				"zone": "{{ .Zone }}",
+			},
+			ServerSettings: &apiv1alpha1.ServerSettings{
+				BIOSSettings: map[string]string{
+					"HyperThreading": "Disabled",
+				},
+				BootMode:               ptr.To(apiv1alpha1.BootModeUEFI),
+				PowerProfile:           ptr.To(apiv1alpha1.PowerProfilePerformance),
+				MinimumFirmwareVersion: ptr.To("2.10.0"),
 			},
+			ReleasePolicy: ptr.To(apiv1alpha1.ReleasePolicyWipe),
 		}
 		workerConfigJSON, _ = json.Marshal(workerConfig)
 
@@ -243,6 +273,19 @@ func SetupTest() (*corev1.Namespace, *gardener.ChartApplier) {
 				APIVersion: apiv1alpha1.SchemeGroupVersion.String(),
 				Kind:       "CloudProfileConfig",
 			},
+			RegionConfigs: []apiv1alpha1.RegionConfig{
+				{
+					Name: "foo",
+					Zones: []apiv1alpha1.ZoneConfig{
+						{
+							Name: "zone1",
+							ServerLabels: map[string]string{
+								"rack": "rack1",
+							},
+						},
+					},
+				},
+			},
 			MachineTypes: []apiv1alpha1.MachineType{
 				{
 					Name: "large",
@@ -316,6 +359,86 @@ func SetupTest() (*corev1.Namespace, *gardener.ChartApplier) {
 	return ns, &chartApplier
 }
 
+// SetupMetalNamespace creates a namespace in the metal cluster and the cloudprovider secret of the test worker, which
+// grants access to it, in the given namespace. It returns the namespace in the metal cluster.
+func SetupMetalNamespace(ctx SpecContext, ns *corev1.Namespace) *corev1.Namespace {
+	metalNamespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{GenerateName: "metal-"}}
+	Expect(k8sClient.Create(ctx, metalNamespace)).To(Succeed())
+	DeferCleanup(k8sClient.Delete, metalNamespace)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: ns.Name,
+			Name:      w.Spec.SecretRef.Name,
+		},
+		Data: map[string][]byte{
+			metal.NamespaceFieldName:  []byte(metalNamespace.Name),
+			metal.KubeConfigFieldName: kubeconfig,
+		},
+	}
+	Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+	DeferCleanup(k8sClient.Delete, secret)
+	w.Spec.SecretRef.Namespace = ns.Name
+
+	return metalNamespace
+}
+
+// AddTestUser adds a user to the test environment, which is only granted the given rules in the given namespace, and
+// returns its kubeconfig.
+func AddTestUser(ctx SpecContext, name, namespace string, rules ...rbacv1.PolicyRule) []byte {
+	user, err := testEnv.AddUser(envtest.User{
+		Name:   name,
+		Groups: []string{"system:authenticated"},
+	}, cfg)
+	Expect(err).NotTo(HaveOccurred())
+	userKubeconfig, err := user.KubeConfig()
+	Expect(err).NotTo(HaveOccurred())
+
+	if len(rules) > 0 {
+		role := &rbacv1.Role{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Rules:      rules,
+		}
+		Expect(k8sClient.Create(ctx, role)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, role)
+
+		roleBinding := &rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: role.Name},
+			Subjects:   []rbacv1.Subject{{APIGroup: rbacv1.GroupName, Kind: rbacv1.UserKind, Name: name}},
+		}
+		Expect(k8sClient.Create(ctx, roleBinding)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, roleBinding)
+	}
+	return userKubeconfig
+}
+
+// SetRegionAdminKubeconfig stores the given kubeconfig as admin credentials of the region of the test worker in the
+// given namespace and configures the worker delegate to use them.
+func SetRegionAdminKubeconfig(ctx SpecContext, ns *corev1.Namespace, delegate *workerDelegate, adminKubeconfig []byte) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: ns.Name, Name: "region-admin"},
+		Data:       map[string][]byte{metal.KubeConfigFieldName: adminKubeconfig},
+	}
+	Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+	DeferCleanup(k8sClient.Delete, secret)
+
+	delegate.tenantBootstrap = config.TenantBootstrap{
+		Regions: []config.TenantBootstrapRegion{{
+			Name:      w.Spec.Region,
+			SecretRef: corev1.SecretReference{Namespace: ns.Name, Name: secret.Name},
+		}},
+	}
+}
+
+// NewTestWorkerDelegate returns the worker delegate of the test worker and cluster.
+func NewTestWorkerDelegate() *workerDelegate {
+	decoder := serializer.NewCodecFactory(k8sClient.Scheme(), serializer.EnableStrict).UniversalDecoder()
+	delegate, err := NewWorkerDelegate(k8sClient, decoder, k8sClient.Scheme(), "", w, testCluster, config.TenantBootstrap{}, config.ServerSanitization{})
+	Expect(err).NotTo(HaveOccurred())
+	return delegate.(*workerDelegate)
+}
+
 func mapToString(m map[string]interface{}) (string, error) {
 	yamlData, err := yaml.Marshal(m)
 	if err != nil {
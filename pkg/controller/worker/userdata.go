@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package worker
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	machinecontrollerv1alpha1 "github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+
+	metalv1alpha1 "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal/v1alpha1"
+)
+
+// maxUserDataSize is the maximum size of the user data and the Ignition of a machine. The metal machine provider
+// stores both in a single Secret in the metal cluster, hence their sum must not exceed the size limit of Secrets.
+const maxUserDataSize = corev1.MaxSecretSize
+
+// maxMachineClassSize is the maximum size of a MachineClass, which is the default limit of etcd for the size of a
+// request. The Ignition is part of the provider spec of the MachineClass of every zone of a worker pool.
+const maxMachineClassSize = 3 * 512 * 1024
+
+// compressUserData returns whether the user data of the worker pool is compressed.
+func compressUserData(workerConfig *metalv1alpha1.WorkerConfig) bool {
+	return workerConfig.UserDataCompression != nil && *workerConfig.UserDataCompression == metalv1alpha1.UserDataCompressionGzip
+}
+
+// encodeGzipBase64 compresses the given data with gzip and encodes the result with base64.
+func encodeGzipBase64(data []byte) ([]byte, error) {
+	var compressed bytes.Buffer
+	writer, err := gzip.NewWriterLevel(&compressed, gzip.BestCompression)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := writer.Write(data); err != nil {
+		return nil, fmt.Errorf("failed to compress data: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress data: %w", err)
+	}
+
+	encoded := make([]byte, base64.StdEncoding.EncodedLen(compressed.Len()))
+	base64.StdEncoding.Encode(encoded, compressed.Bytes())
+	return encoded, nil
+}
+
+// checkUserDataSize ensures that the user data and the Ignition of a machine fit into the Secret the metal machine
+// provider creates for them.
+func checkUserDataSize(userData []byte, ignition string) error {
+	if size := len(userData) + len(ignition); size > maxUserDataSize {
+		return fmt.Errorf("user data and ignition have %d bytes, which exceeds the limit of %d bytes", size, maxUserDataSize)
+	}
+	return nil
+}
+
+// checkMachineClassSize ensures that the given MachineClass fits into a single request to etcd.
+func checkMachineClassSize(machineClass *machinecontrollerv1alpha1.MachineClass) error {
+	data, err := json.Marshal(machineClass)
+	if err != nil {
+		return fmt.Errorf("failed to marshal machine class %s: %w", machineClass.Name, err)
+	}
+	if size := len(data); size > maxMachineClassSize {
+		return fmt.Errorf("machine class %s has %d bytes, which exceeds the limit of %d bytes", machineClass.Name, size, maxMachineClassSize)
+	}
+	return nil
+}
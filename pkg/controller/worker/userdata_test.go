@@ -0,0 +1,68 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package worker
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"strings"
+
+	machinecontrollerv1alpha1 "github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/metal"
+)
+
+var _ = Describe("User data", func() {
+	Describe("#encodeGzipBase64", func() {
+		It("should compress and encode the data", func() {
+			data := []byte(strings.Repeat("#cloud-config\n", 1000))
+
+			encoded, err := encodeGzipBase64(data)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(len(encoded)).To(BeNumerically("<", len(data)))
+
+			compressed, err := base64.StdEncoding.DecodeString(string(encoded))
+			Expect(err).NotTo(HaveOccurred())
+			reader, err := gzip.NewReader(bytes.NewReader(compressed))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(io.ReadAll(reader)).To(Equal(data))
+		})
+	})
+
+	Describe("#checkUserDataSize", func() {
+		It("should allow user data and ignition up to the size limit", func() {
+			Expect(checkUserDataSize(make([]byte, maxUserDataSize-1), "a")).To(Succeed())
+		})
+
+		It("should reject user data and ignition exceeding the size limit", func() {
+			Expect(checkUserDataSize(make([]byte, maxUserDataSize), "a")).To(MatchError(ContainSubstring("exceeds the limit of 1048576 bytes")))
+		})
+	})
+
+	Describe("#checkMachineClassSize", func() {
+		machineClassWithIgnition := func(size int) *machinecontrollerv1alpha1.MachineClass {
+			providerSpec, err := json.Marshal(map[string]any{metal.IgnitionFieldName: strings.Repeat("a", size)})
+			Expect(err).NotTo(HaveOccurred())
+			return &machinecontrollerv1alpha1.MachineClass{
+				ObjectMeta:   metav1.ObjectMeta{Name: "class"},
+				ProviderSpec: runtime.RawExtension{Raw: providerSpec},
+			}
+		}
+
+		It("should allow machine classes up to the size limit", func() {
+			Expect(checkMachineClassSize(machineClassWithIgnition(maxMachineClassSize / 2))).To(Succeed())
+		})
+
+		It("should reject machine classes exceeding the size limit", func() {
+			Expect(checkMachineClassSize(machineClassWithIgnition(maxMachineClassSize))).To(MatchError(ContainSubstring("exceeds the limit of 1572864 bytes")))
+		})
+	})
+})
@@ -0,0 +1,176 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/gardener/gardener/extensions/pkg/controller/worker"
+	"github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/gardener/gardener/pkg/utils"
+	machinecontrollerv1alpha1 "github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	metalv1alpha1 "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal/v1alpha1"
+)
+
+const (
+	// workerPoolHashVersionLegacy is the worker pool hash Gardener computes for every provider, without any metal
+	// specific data. It is used by the worker pools created before the metal specific data was added to the hash.
+	workerPoolHashVersionLegacy int32 = 0
+	// workerPoolHashVersionCurrent adds the metal specific data to the worker pool hash. Data added to the hash in the
+	// future must only be included if it is set, so that the hash of existing worker pools does not change.
+	workerPoolHashVersionCurrent int32 = 1
+)
+
+// deploymentName returns the name of the MachineDeployment of a worker pool zone.
+func (w *workerDelegate) deploymentName(pool v1alpha1.WorkerPool, zoneIndex int) string {
+	return fmt.Sprintf("%s-%s-z%d", w.worker.Namespace, pool.Name, zoneIndex+1)
+}
+
+// machineClassSecretName returns the name of the Secret containing the user data of a worker pool, which is shared by
+// the MachineClasses of all zones of the pool.
+func (w *workerDelegate) machineClassSecretName(pool v1alpha1.WorkerPool, workerPoolHash string) string {
+	return fmt.Sprintf("%s-%s-%s", w.worker.Namespace, pool.Name, workerPoolHash)
+}
+
+// generateHashForWorkerPool generates the hash of the worker pool and returns it together with the version of the hash
+// to record in the provider status of the worker. New worker pools use the current version of the hash. Worker pools
+// using the legacy version keep it, so that updating the extension does not roll the machines of all clusters, until
+// the current version of their hash changes, i.e. until any setting covered by the hash changes.
+func (w *workerDelegate) generateHashForWorkerPool(ctx context.Context, pool v1alpha1.WorkerPool, workerConfig *metalv1alpha1.WorkerConfig, mergedIgnition string) (string, metalv1alpha1.WorkerPoolHash, error) {
+	currentHash, err := w.workerPoolHash(pool, workerConfig, mergedIgnition, workerPoolHashVersionCurrent)
+	if err != nil {
+		return "", metalv1alpha1.WorkerPoolHash{}, err
+	}
+
+	workerStatus, err := w.decodeWorkerProviderStatus()
+	if err != nil {
+		return "", metalv1alpha1.WorkerPoolHash{}, fmt.Errorf("unable to decode the worker provider status: %w", err)
+	}
+
+	version := workerPoolHashVersionCurrent
+	if idx := slices.IndexFunc(workerStatus.WorkerPoolHashes, func(h metalv1alpha1.WorkerPoolHash) bool { return h.Pool == pool.Name }); idx >= 0 {
+		if recorded := workerStatus.WorkerPoolHashes[idx]; recorded.CurrentHash == currentHash {
+			version = recorded.Version
+		}
+	} else if version, err = w.initialWorkerPoolHashVersion(ctx, pool); err != nil {
+		return "", metalv1alpha1.WorkerPoolHash{}, err
+	}
+
+	recorded := metalv1alpha1.WorkerPoolHash{Pool: pool.Name, Version: version, CurrentHash: currentHash}
+	if version == workerPoolHashVersionCurrent {
+		return currentHash, recorded, nil
+	}
+	hash, err := w.workerPoolHash(pool, workerConfig, mergedIgnition, version)
+	return hash, recorded, err
+}
+
+// initialWorkerPoolHashVersion returns the version of the worker pool hash used by a worker pool whose version has not
+// been recorded yet. Worker pools whose MachineDeployments use the legacy hash keep using it.
+func (w *workerDelegate) initialWorkerPoolHashVersion(ctx context.Context, pool v1alpha1.WorkerPool) (int32, error) {
+	legacyHash, err := worker.WorkerPoolHash(pool, w.cluster, nil, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	for zoneIndex := range pool.Zones {
+		deploymentName := w.deploymentName(pool, zoneIndex)
+		machineDeployment := &machinecontrollerv1alpha1.MachineDeployment{}
+		if err := w.client.Get(ctx, client.ObjectKey{Namespace: w.worker.Namespace, Name: deploymentName}, machineDeployment); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return 0, fmt.Errorf("failed to get machine deployment %s: %w", deploymentName, err)
+		}
+		if machineDeployment.Spec.Template.Spec.Class.Name == fmt.Sprintf("%s-%s", deploymentName, legacyHash) {
+			return workerPoolHashVersionLegacy, nil
+		}
+	}
+	return workerPoolHashVersionCurrent, nil
+}
+
+// updateWorkerPoolHashes records the versions of the worker pool hashes in the provider status of the worker.
+func (w *workerDelegate) updateWorkerPoolHashes(ctx context.Context, workerPoolHashes []metalv1alpha1.WorkerPoolHash) error {
+	workerStatus, err := w.decodeWorkerProviderStatus()
+	if err != nil {
+		return fmt.Errorf("unable to decode the worker provider status: %w", err)
+	}
+	if slices.Equal(workerPoolHashes, workerStatus.WorkerPoolHashes) {
+		return nil
+	}
+	workerStatus.WorkerPoolHashes = workerPoolHashes
+	if err := w.updateWorkerProviderStatus(ctx, workerStatus); err != nil {
+		return fmt.Errorf("failed to update worker provider status: %w", err)
+	}
+	return nil
+}
+
+// workerPoolHash generates the hash of the worker pool in the given version.
+func (w *workerDelegate) workerPoolHash(pool v1alpha1.WorkerPool, workerConfig *metalv1alpha1.WorkerConfig, mergedIgnition string, version int32) (string, error) {
+	if version == workerPoolHashVersionLegacy {
+		return worker.WorkerPoolHash(pool, w.cluster, nil, nil)
+	}
+
+	var additionalData []string
+	if mergedIgnition != "" {
+		additionalData = append(additionalData, utils.ComputeSHA256Hex([]byte(mergedIgnition)))
+	}
+
+	for _, zone := range pool.Zones {
+		serverSelector, err := w.getServerSelectorForMachine(pool.MachineType, zone, workerConfig)
+		if err != nil {
+			return "", err
+		}
+		data, err := json.Marshal(serverSelector)
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal server selector of zone %s: %w", zone, err)
+		}
+		additionalData = append(additionalData, string(data))
+	}
+	if len(workerConfig.IPAMConfig) > 0 {
+		data, err := json.Marshal(workerConfig.IPAMConfig)
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal ipam config: %w", err)
+		}
+		additionalData = append(additionalData, string(data))
+	}
+	if len(workerConfig.Metadata) > 0 {
+		data, err := json.Marshal(workerConfig.Metadata)
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal metadata: %w", err)
+		}
+		additionalData = append(additionalData, string(data))
+	}
+
+	if workerConfig.ServerSettings != nil {
+		data, err := json.Marshal(workerConfig.ServerSettings)
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal server settings: %w", err)
+		}
+		additionalData = append(additionalData, string(data))
+	}
+
+	if hostnamePolicy := w.getHostnamePolicy(workerConfig); hostnamePolicy != nil {
+		data, err := json.Marshal(hostnamePolicy)
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal hostname policy: %w", err)
+		}
+		additionalData = append(additionalData, string(data))
+	}
+
+	if len(workerConfig.ZoneIPAMConfigs) > 0 {
+		data, err := json.Marshal(workerConfig.ZoneIPAMConfigs)
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal zone ipam configs: %w", err)
+		}
+		additionalData = append(additionalData, string(data))
+	}
+
+	return worker.WorkerPoolHash(pool, w.cluster, additionalData, additionalData)
+}
@@ -0,0 +1,344 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package ignition
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/apimachinery/pkg/util/version"
+	"sigs.k8s.io/yaml"
+)
+
+// SupportedMajorVersion is the major version of the Ignition specification whose configs are merged by the identity
+// of their entries.
+const SupportedMajorVersion = 3
+
+// Config is a decoded Ignition config. All fields are kept as they were decoded, hence fields which are not known to
+// the extension, e.g. fields of newer specifications, survive merging.
+type Config map[string]any
+
+// keyedLists maps the paths of the lists whose entries are merged by their identity to the field identifying them.
+var keyedLists = map[string]string{
+	"passwd.groups":         "name",
+	"passwd.users":          "name",
+	"storage.directories":   "path",
+	"storage.disks":         "device",
+	"storage.files":         "path",
+	"storage.filesystems":   "device",
+	"storage.links":         "path",
+	"storage.luks":          "name",
+	"storage.raid":          "name",
+	"systemd.units":         "name",
+	"systemd.units.dropins": "name",
+}
+
+// nodeLists are the lists of storage nodes. Nodes of all kinds share their paths.
+var nodeLists = []string{"directories", "files", "links"}
+
+// Parse decodes an Ignition config from YAML or JSON. Configs without version or with a 3.x version must not contain
+// entries without or with duplicate identities, fields unknown to the extension are kept.
+func Parse(data []byte) (Config, error) {
+	cfg := Config{}
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to decode ignition config: %w", err)
+	}
+	if isV3(cfg) {
+		if err := validate(cfg).ToAggregate(); err != nil {
+			return nil, fmt.Errorf("invalid ignition config: %w", err)
+		}
+	}
+	return cfg, nil
+}
+
+// Marshal encodes an Ignition config as YAML. An empty config results in an empty string.
+func Marshal(cfg Config) (string, error) {
+	if len(cfg) == 0 {
+		return "", nil
+	}
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode ignition config: %w", err)
+	}
+	return string(data), nil
+}
+
+// Merge merges the given Ignition configs in order, later configs take precedence over earlier ones. If all configs
+// have no version or a 3.x version, they are merged by the identity of their entries:
+//   - files, directories and links are identified by their path, a later node replaces earlier nodes of
+//     other kinds at the same path,
+//   - disks and filesystems are identified by their device, RAID arrays, LUKS volumes, units, drop-ins,
+//     users and groups by their name,
+//   - entries with the same identity are merged field by field, fields set by the later entry win, objects
+//     are merged recursively and other lists within an entry are replaced,
+//   - kernel arguments are combined, a later config can turn an argument which should exist into one
+//     which should not exist and vice versa,
+//   - the merged config gets the highest version of all configs.
+//
+// Otherwise, the configs are merged like before the Ignition v3 specification was known to the extension: objects are
+// merged recursively, lists are concatenated with the entries of later configs first and other values of later
+// configs win.
+func Merge(configs ...Config) Config {
+	mergeFn := mergeV3
+	if slices.ContainsFunc(configs, func(cfg Config) bool { return !isV3(cfg) }) {
+		mergeFn = mergeLegacy
+	}
+
+	var merged any = map[string]any{}
+	for _, cfg := range configs {
+		if cfg == nil {
+			continue
+		}
+		merged = mergeFn("", merged, map[string]any(cfg))
+	}
+	return merged.(map[string]any)
+}
+
+// mergeV3 merges the overlay value into the base value at the given path of an Ignition v3 config.
+func mergeV3(path string, base, overlay any) any {
+	baseMap, baseIsMap := base.(map[string]any)
+	overlayMap, overlayIsMap := overlay.(map[string]any)
+	baseList, baseIsList := base.([]any)
+	overlayList, overlayIsList := overlay.([]any)
+	baseString, baseIsString := base.(string)
+	overlayString, overlayIsString := overlay.(string)
+
+	switch {
+	case baseIsMap && overlayIsMap:
+		if path == "storage" {
+			baseMap = removeReplacedNodes(baseMap, overlayMap)
+		}
+		merged := maps.Clone(baseMap)
+		for _, k := range slices.Sorted(maps.Keys(overlayMap)) {
+			merged[k] = mergeV3(joinPath(path, k), baseMap[k], overlayMap[k])
+		}
+		if path == "kernelArguments" {
+			setList(merged, "shouldExist", combineArguments(baseMap["shouldExist"], overlayMap["shouldExist"], overlayMap["shouldNotExist"]))
+			setList(merged, "shouldNotExist", combineArguments(baseMap["shouldNotExist"], overlayMap["shouldNotExist"], overlayMap["shouldExist"]))
+		}
+		return merged
+	case baseIsList && overlayIsList && keyedLists[path] != "":
+		return mergeByKey(path, baseList, overlayList, keyedLists[path])
+	case path == "ignition.version" && baseIsString && overlayIsString:
+		return higherVersion(baseString, overlayString)
+	default:
+		return overlay
+	}
+}
+
+// mergeLegacy merges the overlay value into the base value of configs of other versions than Ignition v3.
+func mergeLegacy(path string, base, overlay any) any {
+	baseMap, baseIsMap := base.(map[string]any)
+	overlayMap, overlayIsMap := overlay.(map[string]any)
+	baseList, baseIsList := base.([]any)
+	overlayList, overlayIsList := overlay.([]any)
+
+	switch {
+	case baseIsMap && overlayIsMap:
+		merged := maps.Clone(baseMap)
+		for k, v := range overlayMap {
+			merged[k] = mergeLegacy(joinPath(path, k), baseMap[k], v)
+		}
+		return merged
+	case baseIsList && overlayIsList:
+		return slices.Concat(overlayList, baseList)
+	default:
+		return overlay
+	}
+}
+
+// mergeByKey merges the overlay entries into the base entries. Entries with the same key are merged field by field and
+// keep the position of the base entry, all other overlay entries are appended.
+func mergeByKey(path string, base, overlay []any, keyField string) []any {
+	var (
+		merged = make([]any, 0, len(base)+len(overlay))
+		index  = make(map[string]int, len(base))
+	)
+	for _, entry := range base {
+		index[entryKey(entry, keyField)] = len(merged)
+		merged = append(merged, entry)
+	}
+	for _, entry := range overlay {
+		key := entryKey(entry, keyField)
+		i, ok := index[key]
+		if !ok {
+			index[key] = len(merged)
+			merged = append(merged, entry)
+			continue
+		}
+		merged[i] = mergeV3(path, merged[i], entry)
+	}
+	return merged
+}
+
+// removeReplacedNodes removes the nodes of the base storage whose paths are occupied by nodes of other kinds in the
+// overlay storage, as a path can only be occupied by a single node.
+func removeReplacedNodes(base, overlay map[string]any) map[string]any {
+	overlayPaths := make(map[string]sets.Set[string], len(nodeLists))
+	for _, kind := range nodeLists {
+		overlayPaths[kind] = entryKeys(overlay[kind], keyedLists[joinPath("storage", kind)])
+	}
+
+	result := maps.Clone(base)
+	for _, kind := range nodeLists {
+		nodes, ok := base[kind].([]any)
+		if !ok {
+			continue
+		}
+		replaced := sets.New[string]()
+		for otherKind, paths := range overlayPaths {
+			if otherKind != kind {
+				replaced = replaced.Union(paths)
+			}
+		}
+		setList(result, kind, slices.DeleteFunc(slices.Clone(nodes), func(node any) bool {
+			return replaced.Has(entryKey(node, "path"))
+		}))
+	}
+	return result
+}
+
+// combineArguments appends the overlay arguments to the base arguments, skipping duplicates and the base arguments
+// which are revoked by the overlay.
+func combineArguments(base, overlay, revoked any) []any {
+	var (
+		result  []any
+		seen    = sets.New[string]()
+		exclude = sets.New[string]()
+	)
+	for _, arg := range toList(revoked) {
+		exclude.Insert(fmt.Sprint(arg))
+	}
+	for _, arg := range toList(base) {
+		if key := fmt.Sprint(arg); !exclude.Has(key) && !seen.Has(key) {
+			seen.Insert(key)
+			result = append(result, arg)
+		}
+	}
+	for _, arg := range toList(overlay) {
+		if key := fmt.Sprint(arg); !seen.Has(key) {
+			seen.Insert(key)
+			result = append(result, arg)
+		}
+	}
+	return result
+}
+
+// setList sets the given field to the list, or removes the field if the list is empty.
+func setList(fields map[string]any, field string, list []any) {
+	if len(list) == 0 {
+		delete(fields, field)
+		return
+	}
+	fields[field] = list
+}
+
+func toList(value any) []any {
+	list, _ := value.([]any)
+	return list
+}
+
+// entryKey returns the identity of a list entry, or an empty string if the entry has none.
+func entryKey(entry any, keyField string) string {
+	fields, _ := entry.(map[string]any)
+	key, _ := fields[keyField].(string)
+	return key
+}
+
+func entryKeys(list any, keyField string) sets.Set[string] {
+	keys := sets.New[string]()
+	for _, entry := range toList(list) {
+		keys.Insert(entryKey(entry, keyField))
+	}
+	return keys
+}
+
+func joinPath(path, field string) string {
+	if path == "" {
+		return field
+	}
+	return path + "." + field
+}
+
+// ignitionVersion returns the Ignition version of the config, or an empty string if the config has no version.
+func ignitionVersion(cfg Config) string {
+	section, _ := cfg["ignition"].(map[string]any)
+	v, _ := section["version"].(string)
+	return v
+}
+
+// isV3 returns whether the config has no version or a version of the supported major version of the specification.
+func isV3(cfg Config) bool {
+	v := ignitionVersion(cfg)
+	if v == "" {
+		return true
+	}
+	parsed, err := version.ParseSemantic(v)
+	return err == nil && parsed.Major() == SupportedMajorVersion
+}
+
+// higherVersion returns the higher one of two Ignition v3 versions. An empty version is compatible with any other
+// version.
+func higherVersion(a, b string) string {
+	if a == "" || b == "" {
+		return a + b
+	}
+	va, errA := version.ParseSemantic(a)
+	vb, errB := version.ParseSemantic(b)
+	if errA == nil && errB == nil && vb.LessThan(va) {
+		return a
+	}
+	return b
+}
+
+func validate(cfg Config) field.ErrorList {
+	return validateKeyedLists("", nil, map[string]any(cfg), sets.New[string]())
+}
+
+// validateKeyedLists checks that every entry of the lists merged by identity has a key and that keys are unique. The
+// paths of storage nodes have to be unique across all kinds of nodes and are collected in nodePaths.
+func validateKeyedLists(path string, fldPath *field.Path, value any, nodePaths sets.Set[string]) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	fields, ok := value.(map[string]any)
+	if !ok {
+		return allErrs
+	}
+	for _, k := range slices.Sorted(maps.Keys(fields)) {
+		var (
+			childPath    = joinPath(path, k)
+			childFldPath = fldPath.Child(k)
+		)
+		if fldPath == nil {
+			childFldPath = field.NewPath(k)
+		}
+
+		keyField, keyed := keyedLists[childPath]
+		if !keyed {
+			allErrs = append(allErrs, validateKeyedLists(childPath, childFldPath, fields[k], nodePaths)...)
+			continue
+		}
+
+		seen := sets.New[string]()
+		if path == "storage" && slices.Contains(nodeLists, k) {
+			seen = nodePaths
+		}
+		for i, entry := range toList(fields[k]) {
+			keyPath := childFldPath.Index(i).Child(keyField)
+			switch key := entryKey(entry, keyField); {
+			case key == "":
+				allErrs = append(allErrs, field.Required(keyPath, "must not be empty"))
+			case seen.Has(key):
+				allErrs = append(allErrs, field.Duplicate(keyPath, key))
+			default:
+				seen.Insert(key)
+			}
+			allErrs = append(allErrs, validateKeyedLists(childPath, childFldPath.Index(i), entry, nodePaths)...)
+		}
+	}
+	return allErrs
+}
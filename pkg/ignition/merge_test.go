@@ -0,0 +1,232 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package ignition
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+)
+
+var _ = Describe("Ignition", func() {
+	mustParse := func(data string) Config {
+		cfg, err := Parse([]byte(data))
+		Expect(err).NotTo(HaveOccurred())
+		return cfg
+	}
+
+	Describe("#Parse", func() {
+		It("should parse a YAML config", func() {
+			Expect(mustParse(`
+ignition:
+  version: 3.2.0
+storage:
+  files:
+  - path: /etc/foo
+    mode: 420
+`)).To(Equal(Config{
+				"ignition": map[string]any{"version": "3.2.0"},
+				"storage": map[string]any{"files": []any{
+					map[string]any{"path": "/etc/foo", "mode": float64(420)},
+				}},
+			}))
+		})
+
+		It("should parse a JSON config", func() {
+			Expect(mustParse(`{"systemd":{"units":[{"name":"foo.service","enabled":true}]}}`)).To(Equal(Config{
+				"systemd": map[string]any{"units": []any{
+					map[string]any{"name": "foo.service", "enabled": true},
+				}},
+			}))
+		})
+
+		It("should parse an empty config", func() {
+			Expect(mustParse("")).To(BeEmpty())
+		})
+
+		It("should keep fields which are unknown to the extension", func() {
+			cfg := mustParse(`{"storage":{"luks":[{"name":"root","cex":{"enabled":true}}]},"foo":"bar"}`)
+			Expect(cfg).To(HaveKeyWithValue("foo", "bar"))
+			Expect(cfg).To(HaveKeyWithValue("storage", map[string]any{"luks": []any{
+				map[string]any{"name": "root", "cex": map[string]any{"enabled": true}},
+			}}))
+		})
+
+		It("should reject configs which are no objects", func() {
+			_, err := Parse([]byte("the-ignition"))
+			Expect(err).To(MatchError(ContainSubstring("failed to decode ignition config")))
+		})
+
+		It("should reject entries without or with duplicate identities", func() {
+			_, err := Parse([]byte(`
+storage:
+  files:
+  - path: /etc/foo
+  - contents:
+      source: data:,bar
+  links:
+  - path: /etc/foo
+systemd:
+  units:
+  - name: foo.service
+    dropins:
+    - name: 10-a.conf
+    - name: 10-a.conf
+  - name: foo.service
+`))
+			Expect(err).To(MatchError(And(
+				ContainSubstring("storage.files[1].path: Required value"),
+				ContainSubstring(`storage.links[0].path: Duplicate value: "/etc/foo"`),
+				ContainSubstring(`systemd.units[0].dropins[1].name: Duplicate value: "10-a.conf"`),
+				ContainSubstring(`systemd.units[1].name: Duplicate value: "foo.service"`),
+			)))
+		})
+
+		It("should not validate the identities of entries of other versions", func() {
+			Expect(Parse([]byte(`{"ignition":{"version":"2.3.0"},"systemd":{"units":[{"name":"foo.service"},{"name":"foo.service"}]}}`))).NotTo(BeEmpty())
+		})
+	})
+
+	Describe("#Merge", func() {
+		It("should deduplicate nodes by their path with the last config winning", func() {
+			Expect(Merge(
+				mustParse(`
+storage:
+  directories:
+  - path: /etc/foo
+  files:
+  - path: /etc/bar
+    mode: 420
+    contents:
+      source: data:,bar
+  - path: /etc/baz
+`),
+				mustParse(`
+storage:
+  files:
+  - path: /etc/foo
+  - path: /etc/bar
+    contents:
+      source: data:,newbar
+  links:
+  - path: /etc/baz
+    target: /etc/bar
+`),
+			)).To(Equal(mustParse(`
+storage:
+  files:
+  - path: /etc/bar
+    mode: 420
+    contents:
+      source: data:,newbar
+  - path: /etc/foo
+  links:
+  - path: /etc/baz
+    target: /etc/bar
+`)))
+		})
+
+		It("should merge units and their drop-ins by name", func() {
+			Expect(Merge(
+				mustParse(`
+systemd:
+  units:
+  - name: foo.service
+    contents: foo
+    enabled: true
+    dropins:
+    - name: 10-a.conf
+      contents: a
+    - name: 20-b.conf
+      contents: b
+`),
+				mustParse(`
+systemd:
+  units:
+  - name: foo.service
+    enabled: false
+    dropins:
+    - name: 20-b.conf
+      contents: newb
+  - name: bar.service
+`),
+			)).To(Equal(mustParse(`
+systemd:
+  units:
+  - name: foo.service
+    contents: foo
+    enabled: false
+    dropins:
+    - name: 10-a.conf
+      contents: a
+    - name: 20-b.conf
+      contents: newb
+  - name: bar.service
+`)))
+		})
+
+		It("should merge users and replace their lists", func() {
+			Expect(Merge(
+				mustParse(`{"passwd":{"users":[{"name":"core","sshAuthorizedKeys":["a"],"shell":"/bin/bash"}]}}`),
+				mustParse(`{"passwd":{"users":[{"name":"core","sshAuthorizedKeys":["b"]}]}}`),
+			)).To(Equal(mustParse(`{"passwd":{"users":[{"name":"core","sshAuthorizedKeys":["b"],"shell":"/bin/bash"}]}}`)))
+		})
+
+		It("should merge fields which are unknown to the extension", func() {
+			Expect(Merge(
+				mustParse(`{"a":{"b":"foo"},"storage":{"luks":[{"name":"root","device":"/dev/sda","cex":{"enabled":false}}]}}`),
+				mustParse(`{"a":{"c":"bar"},"storage":{"luks":[{"name":"root","cex":{"enabled":true}}]}}`),
+			)).To(Equal(mustParse(`{"a":{"b":"foo","c":"bar"},"storage":{"luks":[{"name":"root","device":"/dev/sda","cex":{"enabled":true}}]}}`)))
+		})
+
+		It("should combine kernel arguments", func() {
+			Expect(Merge(
+				mustParse(`{"kernelArguments":{"shouldExist":["a","b"],"shouldNotExist":["c"]}}`),
+				mustParse(`{"kernelArguments":{"shouldExist":["c","a"],"shouldNotExist":["b"]}}`),
+			)).To(Equal(mustParse(`{"kernelArguments":{"shouldExist":["a","c"],"shouldNotExist":["b"]}}`)))
+		})
+
+		It("should use the highest version", func() {
+			merged := Merge(
+				mustParse(`{"ignition":{"version":"3.4.0"}}`),
+				mustParse(`{"ignition":{"version":"3.1.0"}}`),
+				mustParse(`{"storage":{"files":[{"path":"/etc/foo"}]}}`),
+			)
+			Expect(merged).To(HaveKeyWithValue("ignition", map[string]any{"version": "3.4.0"}))
+		})
+
+		It("should keep sections which are only set in one config", func() {
+			Expect(Merge(
+				mustParse(`{"storage":{"files":[{"path":"/etc/foo"}]}}`),
+				nil,
+				mustParse(`{"systemd":{"units":[{"name":"foo.service"}]}}`),
+			)).To(Equal(mustParse(`{"storage":{"files":[{"path":"/etc/foo"}]},"systemd":{"units":[{"name":"foo.service"}]}}`)))
+		})
+
+		It("should concatenate the lists of configs of other versions", func() {
+			Expect(Merge(
+				mustParse(`{"ignition":{"version":"2.3.0"},"systemd":{"units":[{"name":"foo.service","enabled":true}]}}`),
+				mustParse(`{"systemd":{"units":[{"name":"foo.service","enabled":false}]}}`),
+			)).To(Equal(mustParse(`{"ignition":{"version":"2.3.0"},"systemd":{"units":[{"name":"foo.service","enabled":false},{"name":"foo.service","enabled":true}]}}`)))
+		})
+	})
+
+	Describe("#Marshal", func() {
+		It("should return an empty string for an empty config", func() {
+			Expect(Marshal(Config{})).To(BeEmpty())
+		})
+
+		It("should marshal a config as YAML", func() {
+			Expect(Marshal(mustParse(`{"systemd":{"units":[{"name":"foo.service","enabled":true}]}}`))).To(Equal(
+				"systemd:\n  units:\n  - enabled: true\n    name: foo.service\n"))
+		})
+	})
+
+	Describe("#Spec", func() {
+		It("should return the config of the spec", func() {
+			Expect((&Spec{Systemd: &Systemd{Units: []Unit{{Name: "foo.service", Enabled: ptr.To(true)}}}}).Config()).To(Equal(
+				mustParse(`{"systemd":{"units":[{"name":"foo.service","enabled":true}]}}`)))
+		})
+	})
+})
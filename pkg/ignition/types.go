@@ -0,0 +1,99 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package ignition
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Spec is the part of an Ignition v3 configuration the extension generates itself.
+type Spec struct {
+	Storage *Storage `json:"storage,omitempty"`
+	Systemd *Systemd `json:"systemd,omitempty"`
+}
+
+// Config returns the Ignition config of the Spec.
+func (s *Spec) Config() (Config, error) {
+	if s == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode ignition config: %w", err)
+	}
+	cfg := Config{}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to decode ignition config: %w", err)
+	}
+	return cfg, nil
+}
+
+// Storage contains the disks, RAID arrays and filesystems of a configuration.
+type Storage struct {
+	Disks       []Disk       `json:"disks,omitempty"`
+	Filesystems []Filesystem `json:"filesystems,omitempty"`
+	Raid        []Raid       `json:"raid,omitempty"`
+}
+
+// Disk is a disk, identified by its device.
+type Disk struct {
+	Device     string      `json:"device"`
+	Partitions []Partition `json:"partitions,omitempty"`
+	WipeTable  *bool       `json:"wipeTable,omitempty"`
+}
+
+// Partition is a partition of a Disk.
+type Partition struct {
+	GUID               *string `json:"guid,omitempty"`
+	Label              *string `json:"label,omitempty"`
+	Number             int     `json:"number,omitempty"`
+	Resize             *bool   `json:"resize,omitempty"`
+	ShouldExist        *bool   `json:"shouldExist,omitempty"`
+	SizeMiB            *int    `json:"sizeMiB,omitempty"`
+	StartMiB           *int    `json:"startMiB,omitempty"`
+	TypeGUID           *string `json:"typeGuid,omitempty"`
+	WipePartitionEntry *bool   `json:"wipePartitionEntry,omitempty"`
+}
+
+// Filesystem is a filesystem, identified by its device.
+type Filesystem struct {
+	Device         string   `json:"device"`
+	Format         *string  `json:"format,omitempty"`
+	Label          *string  `json:"label,omitempty"`
+	MountOptions   []string `json:"mountOptions,omitempty"`
+	Options        []string `json:"options,omitempty"`
+	Path           *string  `json:"path,omitempty"`
+	UUID           *string  `json:"uuid,omitempty"`
+	WipeFilesystem *bool    `json:"wipeFilesystem,omitempty"`
+}
+
+// Raid is a software RAID array, identified by its name.
+type Raid struct {
+	Name    string   `json:"name"`
+	Devices []string `json:"devices,omitempty"`
+	Level   *string  `json:"level,omitempty"`
+	Options []string `json:"options,omitempty"`
+	Spares  *int     `json:"spares,omitempty"`
+}
+
+// Systemd contains the systemd units.
+type Systemd struct {
+	Units []Unit `json:"units,omitempty"`
+}
+
+// Unit is a systemd unit, identified by its name.
+type Unit struct {
+	Name     string   `json:"name"`
+	Contents *string  `json:"contents,omitempty"`
+	Dropins  []Dropin `json:"dropins,omitempty"`
+	Enabled  *bool    `json:"enabled,omitempty"`
+	Mask     *bool    `json:"mask,omitempty"`
+}
+
+// Dropin is a drop-in of a systemd unit, identified by its name.
+type Dropin struct {
+	Name     string  `json:"name"`
+	Contents *string `json:"contents,omitempty"`
+}
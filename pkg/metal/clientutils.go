@@ -10,17 +10,25 @@ import (
 	extensionscontroller "github.com/gardener/gardener/extensions/pkg/controller"
 	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/tools/clientcmd"
+	clientcmdlatest "k8s.io/client-go/tools/clientcmd/api/latest"
+	clientcmdv1 "k8s.io/client-go/tools/clientcmd/api/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 var metalScheme = runtime.NewScheme()
 
 func init() {
+	utilruntime.Must(authenticationv1.AddToScheme(metalScheme))
+	utilruntime.Must(authorizationv1.AddToScheme(metalScheme))
 	utilruntime.Must(corev1.AddToScheme(metalScheme))
+	utilruntime.Must(rbacv1.AddToScheme(metalScheme))
 	utilruntime.Must(extensionsv1alpha1.AddToScheme(metalScheme))
 }
 
@@ -82,3 +90,61 @@ func GetMetalClientAndNamespaceFromSecretRef(ctx context.Context, cl client.Clie
 
 	return c, string(namespace), nil
 }
+
+// GetMetalClientFromSecretRef creates a metal client from the kubeconfig in the provided secret.
+func GetMetalClientFromSecretRef(ctx context.Context, cl client.Client, secretRef *corev1.SecretReference) (client.Client, error) {
+	secret, err := extensionscontroller.GetSecretByReference(ctx, cl, secretRef)
+	if err != nil {
+		return nil, err
+	}
+
+	kubeconfig, ok := secret.Data[KubeConfigFieldName]
+	if !ok {
+		return nil, fmt.Errorf("could not find a kubeconfig in the secret")
+	}
+	clientCfg, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create rest config from secret: %w", err)
+	}
+	c, err := client.New(clientCfg, client.Options{Scheme: metalScheme})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client from secret: %w", err)
+	}
+
+	return c, nil
+}
+
+// NewKubeconfig returns an encoded kubeconfig for the API server of a region which authenticates the given user with
+// a bearer token and defaults to the given namespace.
+func NewKubeconfig(region, server string, certificateAuthorityData []byte, namespace, username, token string) ([]byte, error) {
+	kubeconfig := &clientcmdv1.Config{
+		CurrentContext: region,
+		Clusters: []clientcmdv1.NamedCluster{{
+			Name: region,
+			Cluster: clientcmdv1.Cluster{
+				Server:                   server,
+				CertificateAuthorityData: certificateAuthorityData,
+			},
+		}},
+		AuthInfos: []clientcmdv1.NamedAuthInfo{{
+			Name: username,
+			AuthInfo: clientcmdv1.AuthInfo{
+				Token: token,
+			},
+		}},
+		Contexts: []clientcmdv1.NamedContext{{
+			Name: region,
+			Context: clientcmdv1.Context{
+				Cluster:   region,
+				AuthInfo:  username,
+				Namespace: namespace,
+			},
+		}},
+	}
+
+	raw, err := runtime.Encode(clientcmdlatest.Codec, kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode kubeconfig: %w", err)
+	}
+	return raw, nil
+}
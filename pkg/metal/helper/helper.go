@@ -5,7 +5,9 @@ package helper
 
 import (
 	"fmt"
+	"strconv"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 
 	api "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal"
@@ -43,3 +45,98 @@ func FindImageFromCloudProfile(cloudProfileConfig *api.CloudProfileConfig, image
 
 	return "", fmt.Errorf("could not find an image for name %q and in version %q", imageName, imageVersion)
 }
+
+// ServerLabelsForMachine combines the server labels of the machine type, the extra server labels of a worker pool and
+// the server labels of the zone in the given region. The labels of the zone take precedence, so that the machines of a
+// zone are always placed on servers located in that zone. The match labels of the server selector of the machine type
+// are treated like server labels of the machine type.
+func ServerLabelsForMachine(cloudProfileConfig *api.CloudProfileConfig, region, zone, machineType string, extraServerLabels map[string]string) map[string]string {
+	combinedLabels := make(map[string]string)
+	if t := findMachineType(cloudProfileConfig, machineType); t != nil {
+		for key, value := range t.ServerLabels {
+			combinedLabels[key] = value
+		}
+		if t.ServerSelector != nil {
+			for key, value := range t.ServerSelector.MatchLabels {
+				combinedLabels[key] = value
+			}
+		}
+	}
+	for key, value := range extraServerLabels {
+		combinedLabels[key] = value
+	}
+	for _, regionConfig := range cloudProfileConfig.RegionConfigs {
+		if regionConfig.Name != region {
+			continue
+		}
+		for _, zoneConfig := range regionConfig.Zones {
+			if zoneConfig.Name == zone {
+				for key, value := range zoneConfig.ServerLabels {
+					combinedLabels[key] = value
+				}
+			}
+		}
+	}
+	return combinedLabels
+}
+
+// ServerSelectorForMachine returns the label selector for the servers of a machine. The match labels are the server
+// labels returned by ServerLabelsForMachine, where the match labels of the given server selector of the worker pool
+// are treated like extra server labels. The match expressions of the machine type and the worker pool are combined,
+// so that a server has to fulfill all of them.
+func ServerSelectorForMachine(cloudProfileConfig *api.CloudProfileConfig, region, zone, machineType string, extraServerLabels map[string]string, serverSelector *metav1.LabelSelector) *metav1.LabelSelector {
+	extraLabels := make(map[string]string)
+	if serverSelector != nil {
+		for key, value := range serverSelector.MatchLabels {
+			extraLabels[key] = value
+		}
+	}
+	for key, value := range extraServerLabels {
+		extraLabels[key] = value
+	}
+
+	selector := &metav1.LabelSelector{
+		MatchLabels: ServerLabelsForMachine(cloudProfileConfig, region, zone, machineType, extraLabels),
+	}
+	if t := findMachineType(cloudProfileConfig, machineType); t != nil && t.ServerSelector != nil {
+		selector.MatchExpressions = append(selector.MatchExpressions, t.ServerSelector.MatchExpressions...)
+	}
+	if serverSelector != nil {
+		selector.MatchExpressions = append(selector.MatchExpressions, serverSelector.MatchExpressions...)
+	}
+	return selector
+}
+
+// DiskCountLabel is the server label of a machine type carrying the number of local disks of its Servers.
+const DiskCountLabel = "metal.ironcore.dev/disk-count"
+
+// DiskCountForMachineType returns the number of local disks of the Servers of the given machine type, taken from the
+// DiskCountLabel of its server labels or server selector. It returns false if the machine type does not specify it.
+func DiskCountForMachineType(cloudProfileConfig *api.CloudProfileConfig, machineType string) (int, bool) {
+	t := findMachineType(cloudProfileConfig, machineType)
+	if t == nil {
+		return 0, false
+	}
+
+	value, ok := t.ServerLabels[DiskCountLabel]
+	if !ok && t.ServerSelector != nil {
+		value, ok = t.ServerSelector.MatchLabels[DiskCountLabel]
+	}
+	if !ok {
+		return 0, false
+	}
+	count, err := strconv.Atoi(value)
+	if err != nil || count < 0 {
+		return 0, false
+	}
+	return count, true
+}
+
+func findMachineType(cloudProfileConfig *api.CloudProfileConfig, name string) *api.MachineType {
+	for i, t := range cloudProfileConfig.MachineTypes {
+		if t.Name == name {
+			return &cloudProfileConfig.MachineTypes[i]
+		}
+	}
+	return nil
+}
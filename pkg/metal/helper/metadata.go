@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package helper
+
+import (
+	"regexp"
+)
+
+// MetadataTemplateData contains the values the metadata templates of a WorkerConfig may refer to.
+type MetadataTemplateData struct {
+	// ShootName is the name of the Shoot.
+	ShootName string
+	// ProjectName is the name of the project of the Shoot.
+	ProjectName string
+	// PoolName is the name of the worker pool.
+	PoolName string
+	// Zone is the zone of the MachineClass.
+	Zone string
+	// Region is the region of the Shoot.
+	Region string
+	// KubernetesVersion is the Kubernetes version of the worker pool.
+	KubernetesVersion string
+	// MachineImageName is the name of the machine image of the worker pool.
+	MachineImageName string
+	// MachineImageVersion is the version of the machine image of the worker pool.
+	MachineImageVersion string
+}
+
+// metadataPlaceholder matches a placeholder like {{ .Zone }} in a metadata value.
+var metadataPlaceholder = regexp.MustCompile(`\{\{\s*\.(\w+)\s*\}\}`)
+
+// placeholders returns the values of the placeholders of the given data by their name.
+func (d MetadataTemplateData) placeholders() map[string]string {
+	return map[string]string{
+		"ShootName":           d.ShootName,
+		"ProjectName":         d.ProjectName,
+		"PoolName":            d.PoolName,
+		"Zone":                d.Zone,
+		"Region":              d.Region,
+		"KubernetesVersion":   d.KubernetesVersion,
+		"MachineImageName":    d.MachineImageName,
+		"MachineImageVersion": d.MachineImageVersion,
+	}
+}
+
+// RenderMetadata replaces the known placeholders like {{ .Zone }} in the values of the given metadata with the given
+// data. Everything else, including unknown placeholders and other template syntax, is returned unchanged, so that
+// values which happen to contain braces keep working.
+func RenderMetadata(metadata map[string]string, data MetadataTemplateData) map[string]string {
+	if metadata == nil {
+		return nil
+	}
+
+	placeholders := data.placeholders()
+	rendered := make(map[string]string, len(metadata))
+	for key, value := range metadata {
+		rendered[key] = metadataPlaceholder.ReplaceAllStringFunc(value, func(placeholder string) string {
+			name := metadataPlaceholder.FindStringSubmatch(placeholder)[1]
+			if v, ok := placeholders[name]; ok {
+				return v
+			}
+			return placeholder
+		})
+	}
+	return rendered
+}
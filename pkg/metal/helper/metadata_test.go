@@ -0,0 +1,46 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package helper
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("#RenderMetadata", func() {
+	data := MetadataTemplateData{
+		ShootName:   "shoot",
+		ProjectName: "project",
+		Zone:        "zone1",
+		Region:      "region",
+	}
+
+	It("should replace the known placeholders", func() {
+		Expect(RenderMetadata(map[string]string{
+			"cluster":  "{{ .ProjectName }}/{{ .ShootName }}",
+			"location": "{{.Region}}/{{  .Zone  }}",
+			"static":   "value",
+		}, data)).To(Equal(map[string]string{
+			"cluster":  "project/shoot",
+			"location": "region/zone1",
+			"static":   "value",
+		}))
+	})
+
+	It("should keep unknown placeholders and other template syntax", func() {
+		Expect(RenderMetadata(map[string]string{
+			"unknown":    "{{ .Rack }}",
+			"unparsable": "{{ .Zone",
+			"action":     `{{ if .Zone }}{{ .Zone }}{{ end }}`,
+		}, data)).To(Equal(map[string]string{
+			"unknown":    "{{ .Rack }}",
+			"unparsable": "{{ .Zone",
+			"action":     `{{ if .Zone }}zone1{{ end }}`,
+		}))
+	})
+
+	It("should return nil for nil metadata", func() {
+		Expect(RenderMetadata(nil, data)).To(BeNil())
+	})
+})
@@ -0,0 +1,66 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package metal
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// IPAMGroup is the API group of the IPAM resources in the metal cluster.
+	IPAMGroup = "ipam.metal.ironcore.dev"
+	// NetworkKind is the kind of the IPAM Network resource.
+	NetworkKind = "Network"
+	// SubnetKind is the kind of the IPAM Subnet resource.
+	SubnetKind = "Subnet"
+	// IPKind is the kind of the IPAM IP resource.
+	IPKind = "IP"
+)
+
+var (
+	// IPAMGroupVersion is the group version of the IPAM resources in the metal cluster.
+	IPAMGroupVersion = schema.GroupVersion{Group: IPAMGroup, Version: "v1alpha1"}
+	// NetworkGVK is the GroupVersionKind of the IPAM Network resource.
+	NetworkGVK = IPAMGroupVersion.WithKind(NetworkKind)
+	// SubnetGVK is the GroupVersionKind of the IPAM Subnet resource.
+	SubnetGVK = IPAMGroupVersion.WithKind(SubnetKind)
+	// IPGVK is the GroupVersionKind of the IPAM IP resource.
+	IPGVK = IPAMGroupVersion.WithKind(IPKind)
+)
+
+// NewUnstructured returns an empty unstructured object of the given GroupVersionKind.
+func NewUnstructured(gvk schema.GroupVersionKind, namespace, name string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	return obj
+}
+
+// NewUnstructuredList returns an empty unstructured list for objects of the given GroupVersionKind.
+func NewUnstructuredList(gvk schema.GroupVersionKind) *unstructured.UnstructuredList {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+	return list
+}
+
+// GetIPAMObject gets the IPAM object of the given group, kind and name. The version of the object is resolved with the
+// REST mapper of the client.
+func GetIPAMObject(ctx context.Context, c client.Client, namespace, group, kind, name string) (*unstructured.Unstructured, error) {
+	mapping, err := c.RESTMapper().RESTMapping(schema.GroupKind{Group: group, Kind: kind})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get REST mapping of %s.%s: %w", kind, group, err)
+	}
+
+	obj := NewUnstructured(mapping.GroupVersionKind, namespace, name)
+	if err := c.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
@@ -0,0 +1,39 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package metal
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("IPAM", func() {
+	Describe("#GetIPAMObject", func() {
+		It("should get the object with the version of the REST mapper", func(ctx SpecContext) {
+			restMapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{IPAMGroupVersion})
+			restMapper.Add(NetworkGVK, meta.RESTScopeNamespace)
+			c := fake.NewClientBuilder().WithRESTMapper(restMapper).WithObjects(
+				NewUnstructured(NetworkGVK, "metal", "my-network"),
+			).Build()
+
+			obj, err := GetIPAMObject(ctx, c, "metal", IPAMGroup, NetworkKind, "my-network")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(obj.GroupVersionKind()).To(Equal(NetworkGVK))
+
+			_, err = GetIPAMObject(ctx, c, "metal", IPAMGroup, NetworkKind, "other-network")
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("should fail for unknown kinds", func(ctx SpecContext) {
+			c := fake.NewClientBuilder().WithRESTMapper(meta.NewDefaultRESTMapper(nil)).Build()
+
+			_, err := GetIPAMObject(ctx, c, "metal", IPAMGroup, "Pool", "my-pool")
+			Expect(err).To(MatchError(ContainSubstring("failed to get REST mapping of Pool.ipam.metal.ironcore.dev")))
+		})
+	})
+})
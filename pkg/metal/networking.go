@@ -0,0 +1,16 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package metal
+
+import (
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+)
+
+// ShootIPFamilies returns the IP families of the given shoot. Shoots without explicit IP families are IPv4 only.
+func ShootIPFamilies(shoot *gardencorev1beta1.Shoot) []gardencorev1beta1.IPFamily {
+	if shoot != nil && shoot.Spec.Networking != nil && len(shoot.Spec.Networking.IPFamilies) > 0 {
+		return shoot.Spec.Networking.IPFamilies
+	}
+	return []gardencorev1beta1.IPFamily{gardencorev1beta1.IPFamilyIPv4}
+}
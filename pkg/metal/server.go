@@ -0,0 +1,190 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package metal
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// MetalGroup is the API group of the metal resources in the metal cluster.
+	MetalGroup = "metal.ironcore.dev"
+	// ServerKind is the kind of the Server resource.
+	ServerKind = "Server"
+	// ServerClaimKind is the kind of the ServerClaim resource.
+	ServerClaimKind = "ServerClaim"
+
+	// ServerStateAvailable is the state of a Server which can be claimed.
+	ServerStateAvailable = "Available"
+	// ServerStateReserved is the state of a Server which is claimed by a ServerClaim.
+	ServerStateReserved = "Reserved"
+	// ServerStateTainted is the state of a released Server which has not been sanitized yet.
+	ServerStateTainted = "Tainted"
+)
+
+var (
+	// MetalGroupVersion is the group version of the metal resources in the metal cluster.
+	MetalGroupVersion = schema.GroupVersion{Group: MetalGroup, Version: "v1alpha1"}
+	// ServerGVK is the GroupVersionKind of the Server resource.
+	ServerGVK = MetalGroupVersion.WithKind(ServerKind)
+	// ServerClaimGVK is the GroupVersionKind of the ServerClaim resource.
+	ServerClaimGVK = MetalGroupVersion.WithKind(ServerClaimKind)
+)
+
+// ServerCapacity is the hardware of a Server as reported in its status.
+type ServerCapacity struct {
+	// CPU is the number of logical CPUs of the server.
+	CPU resource.Quantity
+	// Memory is the total system memory of the server.
+	Memory resource.Quantity
+	// Architecture is the CPU architecture of the server, if known.
+	Architecture string
+}
+
+// ListServers returns the Servers matching the server selector.
+func ListServers(ctx context.Context, c client.Reader, serverSelector labels.Selector) ([]unstructured.Unstructured, error) {
+	list := NewUnstructuredList(ServerGVK)
+	if err := c.List(ctx, list, client.MatchingLabelsSelector{Selector: serverSelector}); err != nil {
+		return nil, fmt.Errorf("failed to list Servers: %w", err)
+	}
+	return list.Items, nil
+}
+
+// GetServerCapacity returns the capacity reported in the status of the Server. It returns false if the server has
+// not reported its processors and memory yet.
+func GetServerCapacity(server *unstructured.Unstructured) (ServerCapacity, bool) {
+	memoryString, _, _ := unstructured.NestedString(server.Object, "status", "totalSystemMemory")
+	memory, err := resource.ParseQuantity(memoryString)
+	if err != nil {
+		return ServerCapacity{}, false
+	}
+
+	processors, _, _ := unstructured.NestedSlice(server.Object, "status", "processors")
+	var (
+		cpus         int64
+		architecture string
+	)
+	for _, p := range processors {
+		processor, ok := p.(map[string]any)
+		if !ok {
+			continue
+		}
+		threads, _, _ := unstructured.NestedInt64(processor, "threads")
+		if threads == 0 {
+			threads, _, _ = unstructured.NestedInt64(processor, "cores")
+		}
+		cpus += threads
+		if architecture == "" {
+			architecture, _, _ = unstructured.NestedString(processor, "architecture")
+		}
+	}
+	if cpus == 0 {
+		return ServerCapacity{}, false
+	}
+
+	return ServerCapacity{
+		CPU:          *resource.NewQuantity(cpus, resource.DecimalSI),
+		Memory:       memory,
+		Architecture: normalizeArchitecture(architecture),
+	}, true
+}
+
+// normalizeArchitecture maps the CPU architecture reported by a server to the architecture names used by Gardener.
+func normalizeArchitecture(architecture string) string {
+	switch strings.ToLower(architecture) {
+	case "x86_64", "x86-64", "amd64":
+		return v1beta1constants.ArchitectureAMD64
+	case "aarch64", "arm64":
+		return v1beta1constants.ArchitectureARM64
+	}
+	return ""
+}
+
+// ListClusterServerClaims returns the keys of the ServerClaims of the cluster in the given namespace.
+func ListClusterServerClaims(ctx context.Context, c client.Reader, namespace, clusterName string) (sets.Set[client.ObjectKey], error) {
+	list := NewUnstructuredList(ServerClaimGVK)
+	if err := c.List(ctx, list, client.InNamespace(namespace), client.MatchingLabels{ClusterNameLabel: clusterName}); err != nil {
+		if meta.IsNoMatchError(err) {
+			return sets.New[client.ObjectKey](), nil
+		}
+		return nil, fmt.Errorf("failed to list ServerClaims: %w", err)
+	}
+
+	claims := sets.New[client.ObjectKey]()
+	for _, claim := range list.Items {
+		claims.Insert(client.ObjectKeyFromObject(&claim))
+	}
+	return claims, nil
+}
+
+// ListClusterClaimedServers returns the names of the Servers claimed by the ServerClaims of the cluster in the given
+// namespace, together with the worker pool label of the claiming ServerClaim.
+func ListClusterClaimedServers(ctx context.Context, c client.Reader, namespace, clusterName string) (map[string]string, error) {
+	list := NewUnstructuredList(ServerClaimGVK)
+	if err := c.List(ctx, list, client.InNamespace(namespace), client.MatchingLabels{ClusterNameLabel: clusterName}); err != nil {
+		if meta.IsNoMatchError(err) {
+			return map[string]string{}, nil
+		}
+		return nil, fmt.Errorf("failed to list ServerClaims: %w", err)
+	}
+
+	servers := make(map[string]string, len(list.Items))
+	for _, claim := range list.Items {
+		if serverName, _, _ := unstructured.NestedString(claim.Object, "spec", "serverRef", "name"); serverName != "" {
+			servers[serverName] = claim.GetLabels()[WorkerPoolLabel]
+		}
+	}
+	return servers, nil
+}
+
+// IsServerSanitized returns whether a Server released by a ServerClaim in the given namespace has been sanitized. A
+// Server is still being sanitized as long as it is tainted or still reserved for a ServerClaim in the namespace.
+func IsServerSanitized(server *unstructured.Unstructured, namespace string) bool {
+	state, _, _ := unstructured.NestedString(server.Object, "status", "state")
+	switch state {
+	case ServerStateTainted:
+		return false
+	case ServerStateReserved:
+		claimNamespace, _, _ := unstructured.NestedString(server.Object, "spec", "serverClaimRef", "namespace")
+		return claimNamespace != namespace
+	}
+	return true
+}
+
+// CountAvailableServers returns the number of Servers matching the server selector which are either available or
+// already claimed by one of the given ServerClaims.
+func CountAvailableServers(ctx context.Context, c client.Reader, serverSelector labels.Selector, claims sets.Set[client.ObjectKey]) (int, error) {
+	servers, err := ListServers(ctx, c, serverSelector)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	for _, server := range servers {
+		claimName, _, _ := unstructured.NestedString(server.Object, "spec", "serverClaimRef", "name")
+		if claimName != "" {
+			claimNamespace, _, _ := unstructured.NestedString(server.Object, "spec", "serverClaimRef", "namespace")
+			if claims.Has(client.ObjectKey{Namespace: claimNamespace, Name: claimName}) {
+				count++
+			}
+			continue
+		}
+
+		if state, _, _ := unstructured.NestedString(server.Object, "status", "state"); state == ServerStateAvailable {
+			count++
+		}
+	}
+	return count, nil
+}
@@ -0,0 +1,121 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package metal
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Server", func() {
+	newServer := func(name, state string, labels map[string]string, claimRef map[string]any) *unstructured.Unstructured {
+		server := NewUnstructured(ServerGVK, "", name)
+		server.SetLabels(labels)
+		if claimRef != nil {
+			Expect(unstructured.SetNestedMap(server.Object, claimRef, "spec", "serverClaimRef")).To(Succeed())
+		}
+		Expect(unstructured.SetNestedField(server.Object, state, "status", "state")).To(Succeed())
+		return server
+	}
+
+	Describe("#CountAvailableServers", func() {
+		It("should count available servers and servers claimed by the given claims", func(ctx SpecContext) {
+			rack := map[string]string{"rack": "a"}
+			c := fake.NewClientBuilder().WithObjects(
+				newServer("available", ServerStateAvailable, rack, nil),
+				newServer("discovery", "Discovery", rack, nil),
+				newServer("own-claim", ServerStateReserved, rack, map[string]any{"namespace": "metal", "name": "own"}),
+				newServer("foreign-claim", ServerStateReserved, rack, map[string]any{"namespace": "metal", "name": "foreign"}),
+				newServer("other-rack", ServerStateAvailable, map[string]string{"rack": "b"}, nil),
+			).Build()
+
+			count, err := CountAvailableServers(ctx, c, labels.SelectorFromSet(rack), sets.New(client.ObjectKey{Namespace: "metal", Name: "own"}))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(2))
+		})
+
+		It("should only count servers matching the selector expressions", func(ctx SpecContext) {
+			c := fake.NewClientBuilder().WithObjects(
+				newServer("gen1", ServerStateAvailable, map[string]string{"cpu": "gen1"}, nil),
+				newServer("gen2", ServerStateAvailable, map[string]string{"cpu": "gen2"}, nil),
+				newServer("gen3", ServerStateAvailable, map[string]string{"cpu": "gen3"}, nil),
+				newServer("maintenance", ServerStateAvailable, map[string]string{"cpu": "gen2", "maintenance": "true"}, nil),
+			).Build()
+
+			selector, err := metav1.LabelSelectorAsSelector(&metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "cpu", Operator: metav1.LabelSelectorOpIn, Values: []string{"gen1", "gen2"}},
+					{Key: "maintenance", Operator: metav1.LabelSelectorOpDoesNotExist},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			count, err := CountAvailableServers(ctx, c, selector, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(2))
+		})
+	})
+
+	Describe("#ListClusterClaimedServers", func() {
+		It("should list the servers claimed by the cluster with the worker pool of their claim", func(ctx SpecContext) {
+			newClaim := func(namespace, name, serverName string, labels map[string]string) *unstructured.Unstructured {
+				claim := NewUnstructured(ServerClaimGVK, namespace, name)
+				claim.SetLabels(labels)
+				if serverName != "" {
+					Expect(unstructured.SetNestedField(claim.Object, serverName, "spec", "serverRef", "name")).To(Succeed())
+				}
+				return claim
+			}
+			c := fake.NewClientBuilder().WithObjects(
+				newClaim("metal", "pool-claim", "pool-server", map[string]string{ClusterNameLabel: "shoot--foo--bar", WorkerPoolLabel: "pool"}),
+				newClaim("metal", "unlabelled-claim", "unlabelled-server", map[string]string{ClusterNameLabel: "shoot--foo--bar"}),
+				newClaim("metal", "pending-claim", "", map[string]string{ClusterNameLabel: "shoot--foo--bar"}),
+				newClaim("metal", "other-cluster-claim", "other-server", map[string]string{ClusterNameLabel: "shoot--foo--baz"}),
+				newClaim("other", "other-namespace-claim", "other-namespace-server", map[string]string{ClusterNameLabel: "shoot--foo--bar"}),
+			).Build()
+
+			Expect(ListClusterClaimedServers(ctx, c, "metal", "shoot--foo--bar")).To(Equal(map[string]string{
+				"pool-server":       "pool",
+				"unlabelled-server": "",
+			}))
+		})
+	})
+
+	Describe("#IsServerSanitized", func() {
+		It("should only report servers which are neither tainted nor reserved for the namespace as sanitized", func() {
+			Expect(IsServerSanitized(newServer("tainted", ServerStateTainted, nil, nil), "metal")).To(BeFalse())
+			Expect(IsServerSanitized(newServer("reserved", ServerStateReserved, nil, map[string]any{"namespace": "metal", "name": "claim"}), "metal")).To(BeFalse())
+			Expect(IsServerSanitized(newServer("reclaimed", ServerStateReserved, nil, map[string]any{"namespace": "other", "name": "claim"}), "metal")).To(BeTrue())
+			Expect(IsServerSanitized(newServer("available", ServerStateAvailable, nil, nil), "metal")).To(BeTrue())
+		})
+	})
+
+	Describe("#GetServerCapacity", func() {
+		It("should sum up the threads of all processors", func() {
+			server := newServer("server", ServerStateAvailable, nil, nil)
+			Expect(unstructured.SetNestedField(server.Object, "128Gi", "status", "totalSystemMemory")).To(Succeed())
+			Expect(unstructured.SetNestedSlice(server.Object, []any{
+				map[string]any{"architecture": "aarch64", "cores": int64(16), "threads": int64(32)},
+				map[string]any{"architecture": "aarch64", "cores": int64(16)},
+			}, "status", "processors")).To(Succeed())
+
+			capacity, ok := GetServerCapacity(server)
+			Expect(ok).To(BeTrue())
+			Expect(capacity.CPU.Value()).To(Equal(int64(48)))
+			Expect(capacity.Memory.String()).To(Equal("128Gi"))
+			Expect(capacity.Architecture).To(Equal("arm64"))
+		})
+
+		It("should not report a capacity for servers which have not been discovered yet", func() {
+			_, ok := GetServerCapacity(newServer("server", "Initial", nil, nil))
+			Expect(ok).To(BeFalse())
+		})
+	})
+})
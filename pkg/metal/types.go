@@ -31,6 +31,11 @@ const (
 	KubeConfigFieldName = "kubeconfig"
 	// TokenFieldName is containing the token to access an metal cluster.
 	TokenFieldName = "token"
+	// TenantBootstrapFieldName is the field in a secret which requests a tenant bootstrapped by the extension.
+	TenantBootstrapFieldName = "tenantBootstrap"
+	// TokenRenewalTimeFieldName is the field in a secret which records when the token of a bootstrapped tenant in the
+	// secret has to be renewed.
+	TokenRenewalTimeFieldName = "tokenRenewalTime"
 	// ClusterFieldName is the name of the cluster field
 	ClusterFieldName = "clusterName"
 	// LabelsFieldName is the name of the labels field
@@ -41,6 +46,8 @@ const (
 	ImageFieldName = "image"
 	// ServerLabelsFieldName is the name of the server labels field
 	ServerLabelsFieldName = "serverLabels"
+	// ServerSelectorFieldName is the name of the server selector field
+	ServerSelectorFieldName = "serverSelector"
 	// IgnitionFieldName is the name of the ignition field
 	IgnitionFieldName = "ignition"
 	// IgnitionOverrideFieldName is the name of the ignitionOverride field
@@ -49,8 +56,22 @@ const (
 	MetaDataFieldName = "metaData"
 	// IPAMConfigFieldName is the name of the ipamConfig field
 	IPAMConfigFieldName = "ipamConfig"
+	// ServerSettingsFieldName is the name of the serverSettings field
+	ServerSettingsFieldName = "serverSettings"
+	// HostnamePolicyFieldName is the name of the hostnamePolicy field
+	HostnamePolicyFieldName = "hostnamePolicy"
+	// ReleasePolicyFieldName is the name of the releasePolicy field
+	ReleasePolicyFieldName = "releasePolicy"
+	// UserDataEncodingFieldName is the name of the userDataEncoding field
+	UserDataEncodingFieldName = "userDataEncoding"
+	// IgnitionEncodingFieldName is the name of the ignitionEncoding field
+	IgnitionEncodingFieldName = "ignitionEncoding"
+	// EncodingGzipBase64 is the encoding of gzip compressed and base64 encoded data
+	EncodingGzipBase64 = "gzip+base64"
 	// ClusterNameLabel is the name is the label key of the cluster name
 	ClusterNameLabel = "extension.metal.dev/cluster-name"
+	// WorkerPoolLabel is the label key of the worker pool name
+	WorkerPoolLabel = "extension.metal.dev/worker-pool"
 	// LocalMetalAPIAnnotation is the name of the annotation to mark a seed, which contains a local metal API shoot
 	LocalMetalAPIAnnotation = "metal.ironcore.dev/local-metal-api"
 	// AllowEgressToIstioIngressLabel is the label key to allow egress to the istio ingress gateway
@@ -58,6 +79,9 @@ const (
 
 	// CloudProviderConfigName is the name of the secret containing the cloud provider config.
 	CloudProviderConfigName = "cloud-provider-config"
+	// CloudControllerManagerSecretName is the name of the secret containing the metal credentials of the
+	// cloud-controller-manager of a bootstrapped tenant.
+	CloudControllerManagerSecretName = "cloudprovider-cloud-controller-manager"
 	// CloudControllerManagerName is a constant for the name of the CloudController deployed by the worker controller.
 	CloudControllerManagerName = "cloud-controller-manager"
 	// CloudControllerManagerFeatureGatesKeyName is the key name for the feature gates key in CCM configuration
@@ -81,6 +105,51 @@ const (
 	// MachineControllerManagerMonitoringConfigName is the name of the ConfigMap containing monitoring stack configurations for machine-controller-manager.
 	MachineControllerManagerMonitoringConfigName = "machine-controller-manager-monitoring-config"
 
+	// ConditionTypeCredentialsValid is the condition type of an infrastructure which reports whether the credentials
+	// grant access to the metal cluster.
+	ConditionTypeCredentialsValid = "CredentialsValid"
+	// ConditionTypeSubnetsAllocated is the condition type of an infrastructure which reports whether the IPAM subnets
+	// of the networks have been allocated.
+	ConditionTypeSubnetsAllocated = "SubnetsAllocated"
+	// ConditionTypeLoadBalancerIPsReserved is the condition type of an infrastructure which reports whether the
+	// LoadBalancer prefix has been reserved.
+	ConditionTypeLoadBalancerIPsReserved = "LoadBalancerIPsReserved"
+
+	// ConditionTypeServerCapacityAvailable is the condition type of a worker which reports whether enough servers
+	// match the worker pools to scale them to their maximum.
+	ConditionTypeServerCapacityAvailable = "ServerCapacityAvailable"
+	// ConditionTypeServersSanitized is the condition type of a worker which reports whether the servers released by
+	// machines of worker pools with the Wipe release policy have been sanitized.
+	ConditionTypeServersSanitized = "ServersSanitized"
+
+	// ReasonCredentialsValid is the reason of a true CredentialsValid condition.
+	ReasonCredentialsValid = "CredentialsValid"
+	// ReasonCredentialsInvalid is the reason of a false CredentialsValid condition.
+	ReasonCredentialsInvalid = "CredentialsInvalid"
+	// ReasonSubnetsAllocated is the reason of a true SubnetsAllocated condition.
+	ReasonSubnetsAllocated = "SubnetsAllocated"
+	// ReasonSubnetAllocationFailed is the reason of a false SubnetsAllocated condition.
+	ReasonSubnetAllocationFailed = "SubnetAllocationFailed"
+	// ReasonIPsReserved is the reason of a true LoadBalancerIPsReserved condition.
+	ReasonIPsReserved = "IPsReserved"
+	// ReasonIPReservationPending is the reason of a progressing LoadBalancerIPsReserved condition.
+	ReasonIPReservationPending = "IPReservationPending"
+	// ReasonIPReservationFailed is the reason of a false LoadBalancerIPsReserved condition.
+	ReasonIPReservationFailed = "IPReservationFailed"
+
+	// ReasonServerCapacitySufficient is the reason of a true ServerCapacityAvailable condition.
+	ReasonServerCapacitySufficient = "ServerCapacitySufficient"
+	// ReasonServerCapacityInsufficient is the reason of a false ServerCapacityAvailable condition.
+	ReasonServerCapacityInsufficient = "ServerCapacityInsufficient"
+	// ReasonServerCapacityUnknown is the reason of an unknown ServerCapacityAvailable condition.
+	ReasonServerCapacityUnknown = "ServerCapacityUnknown"
+	// ReasonServersSanitized is the reason of a true ServersSanitized condition.
+	ReasonServersSanitized = "ServersSanitized"
+	// ReasonServerSanitizationPending is the reason of a false ServersSanitized condition.
+	ReasonServerSanitizationPending = "ServerSanitizationPending"
+	// ReasonServerSanitizationUnknown is the reason of an unknown ServersSanitized condition.
+	ReasonServerSanitizationUnknown = "ServerSanitizationUnknown"
+
 	// FieldOwner for server side apply
 	FieldOwner client.FieldOwner = ProviderName
 )
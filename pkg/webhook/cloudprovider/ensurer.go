@@ -6,6 +6,7 @@ package cloudprovider
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"github.com/gardener/gardener/extensions/pkg/webhook/cloudprovider"
 	gcontext "github.com/gardener/gardener/extensions/pkg/webhook/context"
@@ -13,8 +14,6 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
-	clientcmdlatest "k8s.io/client-go/tools/clientcmd/api/latest"
-	clientcmdv1 "k8s.io/client-go/tools/clientcmd/api/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
@@ -39,7 +38,23 @@ type ensurer struct {
 
 // EnsureCloudProviderSecret ensures that cloudprovider secret contains
 // the shared credentials file.
-func (e *ensurer) EnsureCloudProviderSecret(ctx context.Context, gctx gcontext.GardenContext, newCloudProviderSecret, _ *corev1.Secret) error {
+func (e *ensurer) EnsureCloudProviderSecret(ctx context.Context, gctx gcontext.GardenContext, newCloudProviderSecret, oldCloudProviderSecret *corev1.Secret) error {
+	if string(newCloudProviderSecret.Data[metal.TenantBootstrapFieldName]) == "true" {
+		// The service account of a bootstrapped tenant and the renewal time of its token are written by the
+		// infrastructure controller and have to survive updates of the secret content from the garden.
+		if oldCloudProviderSecret != nil {
+			for _, key := range []string{metal.TokenFieldName, metal.TokenRenewalTimeFieldName, metal.NamespaceFieldName, metal.UsernameFieldName} {
+				if _, ok := newCloudProviderSecret.Data[key]; !ok && oldCloudProviderSecret.Data[key] != nil {
+					newCloudProviderSecret.Data[key] = oldCloudProviderSecret.Data[key]
+				}
+			}
+		}
+		if _, ok := newCloudProviderSecret.Data[metal.TokenFieldName]; !ok {
+			e.logger.Info("Tenant has not been bootstrapped yet, skipping kubeconfig generation", "secret", client.ObjectKeyFromObject(newCloudProviderSecret))
+			return nil
+		}
+	}
+
 	token, ok := newCloudProviderSecret.Data[metal.TokenFieldName]
 	if !ok {
 		return fmt.Errorf("could not mutate cloudprovider secret as %q field is missing", metal.TokenFieldName)
@@ -67,43 +82,17 @@ func (e *ensurer) EnsureCloudProviderSecret(ctx context.Context, gctx gcontext.G
 		return fmt.Errorf("could not decode cluster object's providerConfig: %w", err)
 	}
 
-	kubeconfig := &clientcmdv1.Config{
-		CurrentContext: cluster.Shoot.Spec.Region,
-		Clusters: []clientcmdv1.NamedCluster{{
-			Name: cluster.Shoot.Spec.Region,
-		}},
-		AuthInfos: []clientcmdv1.NamedAuthInfo{{
-			Name: string(username),
-			AuthInfo: clientcmdv1.AuthInfo{
-				Token: string(token),
-			},
-		}},
-		Contexts: []clientcmdv1.NamedContext{{
-			Name: cluster.Shoot.Spec.Region,
-			Context: clientcmdv1.Context{
-				Cluster:   cluster.Shoot.Spec.Region,
-				AuthInfo:  string(username),
-				Namespace: string(namespace),
-			},
-		}},
-	}
-
-	var regionFound bool
-	for _, region := range cloudProfileConfig.RegionConfigs {
-		if region.Name == cluster.Shoot.Spec.Region {
-			kubeconfig.Clusters[0].Cluster.Server = region.Server
-			kubeconfig.Clusters[0].Cluster.CertificateAuthorityData = region.CertificateAuthorityData
-			regionFound = true
-			break
-		}
-	}
-	if !regionFound {
+	idx := slices.IndexFunc(cloudProfileConfig.RegionConfigs, func(region apismetal.RegionConfig) bool {
+		return region.Name == cluster.Shoot.Spec.Region
+	})
+	if idx < 0 {
 		return fmt.Errorf("faild to find region %s in cloudprofile", cluster.Shoot.Spec.Region)
 	}
+	region := cloudProfileConfig.RegionConfigs[idx]
 
-	raw, err = runtime.Encode(clientcmdlatest.Codec, kubeconfig)
+	raw, err = metal.NewKubeconfig(region.Name, region.Server, region.CertificateAuthorityData, string(namespace), string(username), string(token))
 	if err != nil {
-		return fmt.Errorf("failed to encode kubeconfig: %w", err)
+		return err
 	}
 
 	newCloudProviderSecret.Data[metal.KubeConfigFieldName] = raw
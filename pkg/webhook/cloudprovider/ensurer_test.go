@@ -170,5 +170,31 @@ var _ = Describe("Ensurer", func() {
 			err := ensurer.EnsureCloudProviderSecret(ctx, eContextK8s, secretWithoutUsername, nil)
 			Expect(err).To(HaveOccurred())
 		})
+
+		It("should skip a cloudprovider secret of a tenant which has not been bootstrapped yet", func() {
+			tenantSecret := &corev1.Secret{
+				Data: map[string][]byte{"tenantBootstrap": []byte("true")},
+			}
+
+			Expect(ensurer.EnsureCloudProviderSecret(ctx, eContextK8s, tenantSecret, nil)).To(Succeed())
+			Expect(tenantSecret.Data).NotTo(HaveKey("kubeconfig"))
+		})
+
+		It("should keep the service account of a bootstrapped tenant", func() {
+			tenantSecret := &corev1.Secret{
+				Data: map[string][]byte{"tenantBootstrap": []byte("true")},
+			}
+			oldSecret := secret.DeepCopy()
+			oldSecret.Data["tenantBootstrap"] = []byte("true")
+			oldSecret.Data["tokenRenewalTime"] = []byte("2024-01-01T00:00:00Z")
+
+			Expect(ensurer.EnsureCloudProviderSecret(ctx, eContextK8s, tenantSecret, oldSecret)).To(Succeed())
+			Expect(tenantSecret.Data).To(SatisfyAll(
+				HaveKeyWithValue("token", []byte("bar")),
+				HaveKeyWithValue("tokenRenewalTime", []byte("2024-01-01T00:00:00Z")),
+				HaveKeyWithValue("namespace", []byte("foo")),
+				HaveKey("kubeconfig"),
+			))
+		})
 	})
 })
@@ -153,12 +153,6 @@ func (e *ensurer) EnsureKubeletServiceUnitOptions(_ context.Context, _ extension
 		opt.Value = extensionswebhook.SerializeCommandLine(command, 1, " \\\n    ")
 	}
 
-	new = extensionswebhook.EnsureUnitOption(new, &unit.UnitOption{
-		Section: "Service",
-		Name:    "ExecStartPre",
-		Value:   `/bin/sh -c 'hostnamectl set-hostname $(hostname -f)'`,
-	})
-
 	return new, nil
 }
 
@@ -196,8 +196,7 @@ var _ = Describe("Ensurer", func() {
 
 	Describe("#EnsureKubeletServiceUnitOptions", func() {
 		var (
-			oldUnitOptions        []*unit.UnitOption
-			hostnamectlUnitOption *unit.UnitOption
+			oldUnitOptions []*unit.UnitOption
 		)
 
 		BeforeEach(func() {
@@ -209,12 +208,6 @@ var _ = Describe("Ensurer", func() {
 	    --config=/var/lib/kubelet/config/kubelet`,
 				},
 			}
-
-			hostnamectlUnitOption = &unit.UnitOption{
-				Section: "Service",
-				Name:    "ExecStartPre",
-				Value:   `/bin/sh -c 'hostnamectl set-hostname $(hostname -f)'`,
-			}
 		})
 
 		It("should modify existing elements of kubelet.service unit options",
@@ -225,7 +218,6 @@ var _ = Describe("Ensurer", func() {
 						Name:    "ExecStart",
 						Value:   "/opt/bin/hyperkube kubelet \\\n    --config=/var/lib/kubelet/config/kubelet \\\n    --cloud-provider=external",
 					},
-					hostnamectlUnitOption,
 				}
 
 				opts, err := ensurer.EnsureKubeletServiceUnitOptions(ctx, dummyContext, semver.MustParse("1.23.0"), oldUnitOptions, nil)